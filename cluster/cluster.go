@@ -3,6 +3,8 @@ package cluster
 import (
 	"context"
 	"net/http"
+
+	"github.com/astranet/meshRPC/retry"
 )
 
 type ErrHandlerFunc func(err error)
@@ -30,7 +32,8 @@ type Cluster interface {
 	Join(nodes []string) error
 	// Publish exposes provided Handler to the private net under own service name.
 	// Eeach func that is http.HandlerFunc is being mapped to URI and connected
-	// to the internal HTTP router (Gin). If HandlerSpec implements HTTPMethodsSpec,
+	// to the internal HTTP router (Gin). Both func(*httpserve.Context) httpserve.Response
+	// and func(http.ResponseWriter, *http.Request) signatures are accepted. If HandlerSpec implements HTTPMethodsSpec,
 	// a methods map is used, otherwise all methods are accepted and it's a client
 	// responsibility to use the correct one.
 	Publish(spec HandlerSpec) error
@@ -54,6 +57,12 @@ type Cluster interface {
 	NewClient(serviceName string, spec HandlerSpec, fn ...string) Client
 }
 
+// TargetHeader is a request header that makes Client send the request to the
+// specific instance of the service, instead of a load-balanced one. The value is
+// the address of the instance in the cluster network. It's only honored by Do,
+// not by the reverse proxy of Client.
+const TargetHeader = "X-MeshRPC-Target"
+
 // Client is used for accessing published endpoints of services. It combines a
 // fail-safe proxy for exposing remotely published http.HandlerFunc as local
 // http.Handler, also a http.Client like function Do to send custom requests to
@@ -63,6 +72,10 @@ type Client interface {
 
 	Do(req *http.Request) (*http.Response, error)
 	Use(fnName string) Client
+	// WithRetryPolicy returns a copy of the client that retries requests sent with Do
+	// by the policy, nil disables retries. Only requests with safe HTTP methods, that is
+	// GET, HEAD and OPTIONS, are retried, RPC calls are retried by generated clients instead.
+	WithRetryPolicy(policy *retry.Policy) Client
 }
 
 type Error struct {
@@ -13,6 +13,7 @@ import (
 	"net/http"
 	"net/http/httputil"
 	"net/url"
+	"path"
 	"strings"
 	"sync"
 	"time"
@@ -21,12 +22,20 @@ import (
 
 	"github.com/astranet/astranet"
 	"github.com/astranet/httpserve"
+
+	"github.com/astranet/meshRPC/intercept"
+	"github.com/astranet/meshRPC/metrics"
+	"github.com/astranet/meshRPC/retry"
+	"github.com/astranet/meshRPC/trace"
 )
 
 type AstraOptions struct {
 	Tags  []string
 	Nodes []string
 	Debug bool
+	// RetryPolicy retries requests of clients, see Client.WithRetryPolicy.
+	// Requests are sent once if not set, e.g. use retry.DefaultPolicy to turn retries on.
+	RetryPolicy *retry.Policy
 }
 
 func checkAstraOptions(opt *AstraOptions) *AstraOptions {
@@ -63,6 +72,7 @@ func NewAstraCluster(serviceName string, opt *AstraOptions) Cluster {
 
 		serviceName: serviceName,
 		router:      httpserve.New(),
+		retry:       opt.RetryPolicy,
 	}
 	c.initRouter()
 	if len(opt.Nodes) > 0 {
@@ -75,6 +85,7 @@ func (a *astraCluster) initRouter() {
 	a.router.GET("/ping", okLoopback())
 	a.router.GET("/__heartbeat__", okLoopback())
 	a.router.POST("/__error__", errLoopback())
+	a.router.GET("/metrics", metricsHandler())
 }
 
 type astraCluster struct {
@@ -85,6 +96,7 @@ type astraCluster struct {
 
 	serviceName string
 	router      *httpserve.Serve
+	retry       *retry.Policy
 }
 
 const defaultAstraPort = "11999"
@@ -140,20 +152,45 @@ func (a *astraCluster) ListenAndServe(addr string) error {
 	return nil
 }
 
-func newHTTPTransport(aNet astranet.AstraNet) *http.Transport {
-	return &http.Transport{
-		DisableKeepAlives:     true,
-		ResponseHeaderTimeout: time.Minute,
-		Dial: func(network, addr string) (net.Conn, error) {
-			host, _, err := net.SplitHostPort(addr)
-			if err != nil {
-				return nil, err
-			}
-			return aNet.Dial(network, host)
+// defaultResponseHeaderTimeout limits the wait for response headers of requests
+// that have no deadline of their own.
+const defaultResponseHeaderTimeout = time.Minute
+
+func newHTTPTransport(aNet astranet.AstraNet) http.RoundTripper {
+	dial := func(network, addr string) (net.Conn, error) {
+		host, _, err := net.SplitHostPort(addr)
+		if err != nil {
+			return nil, err
+		}
+		return aNet.Dial(network, host)
+	}
+	return &deadlineTransport{
+		deadline: &http.Transport{
+			DisableKeepAlives: true,
+			Dial:              dial,
+		},
+		fallback: &http.Transport{
+			DisableKeepAlives:     true,
+			ResponseHeaderTimeout: defaultResponseHeaderTimeout,
+			Dial:                  dial,
 		},
 	}
 }
 
+// deadlineTransport lets requests with a context deadline wait for the response
+// until the deadline, others fall back to defaultResponseHeaderTimeout.
+type deadlineTransport struct {
+	deadline *http.Transport
+	fallback *http.Transport
+}
+
+func (t *deadlineTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if _, ok := req.Context().Deadline(); ok {
+		return t.deadline.RoundTrip(req)
+	}
+	return t.fallback.RoundTrip(req)
+}
+
 func (a *astraCluster) ListenAndServeHTTP(addr string) error {
 	if a.dbg {
 		log.Infoln("ListenAndServeHTTP on", addr)
@@ -324,6 +361,7 @@ func (a *astraCluster) NewClient(serviceName string, spec HandlerSpec, nameOpt .
 		cli: &http.Client{
 			Transport: newHTTPTransport(a.net),
 		},
+		retry: a.retry,
 	}
 	if len(fnName) > 0 {
 		cli.enableReverseProxy()
@@ -332,7 +370,7 @@ func (a *astraCluster) NewClient(serviceName string, spec HandlerSpec, nameOpt .
 }
 
 func (a *astraClient) enableReverseProxy() {
-	a.Handler = &httputil.ReverseProxy{
+	proxy := &httputil.ReverseProxy{
 		Transport:     newHTTPTransport(a.net),
 		FlushInterval: time.Millisecond * 10,
 		Director: func(req *http.Request) {
@@ -356,10 +394,87 @@ func (a *astraClient) enableReverseProxy() {
 			if req.URL != nil {
 				query = req.URL.RawQuery
 			}
+			// TargetHeader is not honored here, since the proxy may serve public requests
 			req.URL, _ = url.Parse("http://" + serviceFQDN(a.endpoint.Service) + a.endpoint.Path)
 			req.URL.RawQuery = query
+			startProxySpan(req, a.endpoint.Service)
 		},
+		ModifyResponse: func(resp *http.Response) error {
+			if span := trace.FromContext(resp.Request.Context()); span != nil {
+				span.SetStatus(resp.StatusCode)
+				span.Finish(nil)
+			}
+			return nil
+		},
+		ErrorHandler: func(w http.ResponseWriter, req *http.Request, err error) {
+			status := http.StatusBadGateway
+			if req.Context().Err() == context.Canceled {
+				// the caller has gone away, and so has the call into the service
+				status = intercept.StatusClientClosedRequest
+			} else {
+				log.WithFields(log.Fields{
+					"layer": "cluster",
+					"fn":    "ReverseProxy",
+				}).Warningln(err)
+			}
+			if span := trace.FromContext(req.Context()); span != nil {
+				span.SetStatus(status)
+				span.Finish(err)
+			}
+			w.WriteHeader(status)
+		},
+	}
+	a.Handler = http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		started := time.Now()
+		sw := &statusWriter{ResponseWriter: w}
+		proxy.ServeHTTP(sw, req)
+		metrics.DefaultRegistry.Observe("proxy", a.endpoint.Service, path.Base(a.endpoint.Path),
+			time.Since(started), sw.Status())
+	})
+}
+
+// statusWriter keeps the status of the response, so proxied calls could be recorded in metrics.
+type statusWriter struct {
+	http.ResponseWriter
+	status int
+}
+
+func (w *statusWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *statusWriter) Write(p []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	return w.ResponseWriter.Write(p)
+}
+
+// Flush lets the proxy flush streamed responses, see FlushInterval.
+func (w *statusWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Status returns the status of the response, 200 if nothing has been written.
+func (w *statusWriter) Status() int {
+	if w.status == 0 {
+		return http.StatusOK
 	}
+	return w.status
+}
+
+// startProxySpan starts a span of the proxied request as a child of the caller's one,
+// and passes it on to the service in place of the caller's traceparent.
+func startProxySpan(req *http.Request, service string) {
+	ctx, span := trace.Start(req.Context(), service+req.URL.Path, trace.KindProxy, req.Header)
+	span.SetAttribute("http.method", req.Method)
+	span.Inject(req.Header)
+	*req = *req.WithContext(ctx)
 }
 
 type astraClient struct {
@@ -369,6 +484,7 @@ type astraClient struct {
 	endpoint  *EndpointInfo
 	localhost string
 	cli       *http.Client
+	retry     *retry.Policy
 }
 
 func (a *astraClient) Use(fnName string) Client {
@@ -383,6 +499,7 @@ func (a *astraClient) Use(fnName string) Client {
 		endpoint:  &endpoint,
 		localhost: a.localhost,
 		cli:       a.cli,
+		retry:     a.retry,
 	}
 	if cli.Handler == nil {
 		cli.enableReverseProxy()
@@ -390,11 +507,17 @@ func (a *astraClient) Use(fnName string) Client {
 	return cli
 }
 
+func (a *astraClient) WithRetryPolicy(policy *retry.Policy) Client {
+	cli := *a
+	cli.retry = policy
+	return &cli
+}
+
 func (a *astraClient) Do(req *http.Request) (*http.Response, error) {
 	if a.cli == nil {
 		return nil, nil
 	} else if a.endpoint == nil {
-		return a.cli.Do(req)
+		return a.doRetry(req)
 	}
 	path := a.endpoint.Path
 	if fnName := req.URL.String(); len(fnName) > 0 {
@@ -408,14 +531,86 @@ func (a *astraClient) Do(req *http.Request) (*http.Response, error) {
 	if req.URL != nil {
 		query = req.URL.RawQuery
 	}
-	req.URL, _ = url.Parse("http://" + serviceFQDN(a.endpoint.Service) + path)
+	// a shallow copy, so the URL and headers of the caller's request are left intact
+	req = req.WithContext(req.Context())
+	host, err := a.targetHost(req)
+	if err != nil {
+		return nil, err
+	}
+	req.URL, _ = url.Parse("http://" + host + path)
 	req.URL.RawQuery = query
 	if !a.endpoint.MethodAllowed(req.Method) {
 		err := fmt.Errorf("cluster client: method %s not allowed for %s: must be %s",
 			req.Method, path, strings.Join(a.endpoint.Methods, ","))
 		return nil, err
 	}
-	return a.cli.Do(req)
+	return a.doRetry(req)
+}
+
+// errRetryStatus fails attempts that got a response with a status to retry.
+var errRetryStatus = errors.New("cluster client: retry status")
+
+// doRetry sends the request, retrying it by the retry policy if the request is safe
+// to repeat. The response of the last attempt is returned, even if it has a status to retry.
+func (a *astraClient) doRetry(req *http.Request) (*http.Response, error) {
+	if a.retry == nil || !safeMethod(req.Method) || (req.Body != nil && req.GetBody == nil) {
+		return a.cli.Do(req)
+	}
+	var resp *http.Response
+	err := a.retry.Do(req.Context(), func() (int, error) {
+		if resp != nil {
+			// the previous attempt is being retried
+			ioutil.ReadAll(resp.Body)
+			resp.Body.Close()
+			resp = nil
+		}
+		attemptReq := req
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return http.StatusBadRequest, err
+			}
+			attemptReq = req.WithContext(req.Context())
+			attemptReq.Body = body
+		}
+		r, err := a.cli.Do(attemptReq)
+		if err != nil {
+			return 0, err
+		}
+		resp = r
+		if a.retry.Retryable(r.StatusCode, nil) {
+			return r.StatusCode, errRetryStatus
+		}
+		return r.StatusCode, nil
+	})
+	if resp != nil {
+		return resp, nil
+	}
+	return nil, err
+}
+
+func safeMethod(method string) bool {
+	switch method {
+	case "", "GET", "HEAD", "OPTIONS":
+		return true
+	default:
+		return false
+	}
+}
+
+// targetHost returns the host to dial for the request, it's either the service
+// or the specific instance, if requested with TargetHeader. The header is dropped
+// from a clone of the request headers, so the ones of the caller are left intact.
+func (a *astraClient) targetHost(req *http.Request) (string, error) {
+	if target := req.Header.Get(TargetHeader); len(target) > 0 {
+		req.Header = req.Header.Clone()
+		req.Header.Del(TargetHeader)
+		return target, nil
+	}
+	if a.endpoint == nil {
+		return "", errors.New("cluster client: no endpoint to send the request to")
+	}
+	return serviceFQDN(a.endpoint.Service), nil
 }
 
 func rewritePath(fnName string, path string) string {
@@ -433,6 +628,14 @@ func okLoopback() httpserve.Handler {
 	}
 }
 
+// metricsHandler serves metrics.DefaultRegistry in the Prometheus text format.
+func metricsHandler() httpserve.Handler {
+	return func(c *httpserve.Context) httpserve.Response {
+		metrics.DefaultRegistry.ServeHTTP(c.Writer, c.Request)
+		return httpserve.NewAdoptResponse()
+	}
+}
+
 func errLoopback() httpserve.Handler {
 	return func(c *httpserve.Context) httpserve.Response {
 		var e proxyError
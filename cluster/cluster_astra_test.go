@@ -0,0 +1,109 @@
+package cluster
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/astranet/meshRPC/retry"
+)
+
+func TestStatusWriter(t *testing.T) {
+	tests := []struct {
+		name  string
+		serve func(w http.ResponseWriter)
+		want  int
+	}{{
+		name:  "nothing written",
+		serve: func(w http.ResponseWriter) {},
+		want:  http.StatusOK,
+	}, {
+		name:  "body only",
+		serve: func(w http.ResponseWriter) { w.Write([]byte("ok")) },
+		want:  http.StatusOK,
+	}, {
+		name: "status",
+		serve: func(w http.ResponseWriter) {
+			w.WriteHeader(http.StatusBadGateway)
+			w.WriteHeader(http.StatusOK)
+			w.(http.Flusher).Flush()
+		},
+		want: http.StatusBadGateway,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			sw := &statusWriter{ResponseWriter: rec}
+			tt.serve(sw)
+			if got := sw.Status(); got != tt.want || rec.Code != tt.want {
+				t.Errorf("Status() = %d, recorded %d, want %d", got, rec.Code, tt.want)
+			}
+		})
+	}
+}
+
+func TestDoRetry(t *testing.T) {
+	var attempts int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	opt := checkAstraOptions(nil)
+	if opt.RetryPolicy != nil {
+		t.Errorf("RetryPolicy = %+v by default, want retries off", opt.RetryPolicy)
+	}
+	cli := &astraClient{cli: srv.Client(), retry: opt.RetryPolicy}
+	policy := &retry.Policy{MaxAttempts: 3, MinBackoff: time.Millisecond}
+	tests := []struct {
+		name   string
+		cli    Client
+		method string
+		want   int
+	}{
+		{"no policy", cli, "GET", 1},
+		{"safe method", cli.WithRetryPolicy(policy), "GET", 3},
+		{"unsafe method", cli.WithRetryPolicy(policy), "POST", 1},
+	}
+	for _, tt := range tests {
+		attempts = 0
+		req, _ := http.NewRequest(tt.method, srv.URL, nil)
+		resp, err := tt.cli.Do(req)
+		if err != nil {
+			t.Errorf("%s: Do() error = %v", tt.name, err)
+			continue
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusServiceUnavailable || attempts != tt.want {
+			t.Errorf("%s: Do() = %d after %d attempts, want 503 after %d", tt.name, resp.StatusCode, attempts, tt.want)
+		}
+	}
+}
+
+func TestTargetHost(t *testing.T) {
+	cli := &astraClient{endpoint: &EndpointInfo{Service: "greeter"}}
+	header := make(http.Header)
+	header.Set(TargetHeader, "10.0.0.1:8080")
+	req := &http.Request{Header: header}
+	host, err := cli.targetHost(req)
+	if err != nil || host != "10.0.0.1:8080" {
+		t.Errorf("targetHost() = %q, %v, want the target instance", host, err)
+	}
+	if len(req.Header.Get(TargetHeader)) > 0 {
+		t.Errorf("%s is sent along with the request", TargetHeader)
+	}
+	if len(header.Get(TargetHeader)) == 0 {
+		t.Errorf("%s is dropped from the headers of the caller", TargetHeader)
+	}
+
+	host, err = cli.targetHost(&http.Request{Header: make(http.Header)})
+	if err != nil || host != serviceFQDN("greeter") {
+		t.Errorf("targetHost() = %q, %v, want the service", host, err)
+	}
+	cli.endpoint = nil
+	if _, err := cli.targetHost(&http.Request{Header: make(http.Header)}); err == nil {
+		t.Error("targetHost() without an endpoint succeeded")
+	}
+}
@@ -3,6 +3,7 @@ package cluster
 import (
 	"errors"
 	"fmt"
+	"net/http"
 	"reflect"
 	"strings"
 
@@ -22,6 +23,37 @@ func httpMethodsOf(spec HandlerSpec) map[string][]string {
 	return nil
 }
 
+// MethodDocsSpec is implemented by generated RPC handlers, it provides
+// doc comments of the service interface methods.
+type MethodDocsSpec interface {
+	MethodDocs() map[string]string
+}
+
+func methodDocsOf(spec HandlerSpec) map[string]string {
+	if s, ok := spec.(MethodDocsSpec); ok {
+		return s.MethodDocs()
+	}
+	return nil
+}
+
+// BatchMethod is the last path element of the batch endpoint, i.e. /<handler>/__batch__.
+// It can't clash with handler methods, since those are exported.
+const BatchMethod = "__batch__"
+
+// BatchSpec is implemented by generated RPC handlers, the handler serves
+// several calls in a single HTTP exchange at the BatchMethod path.
+type BatchSpec interface {
+	BatchHandler() http.Handler
+}
+
+var batchSpecTyp = reflect.TypeOf((*BatchSpec)(nil)).Elem()
+
+// DescribeEndpoints returns endpoints of the HandlerSpec as they would be published,
+// along with doc comments if the spec implements MethodDocsSpec.
+func DescribeEndpoints(serviceName string, spec HandlerSpec) ([]*EndpointInfo, error) {
+	return reflectEndpoints(serviceName, spec)
+}
+
 func reflectEndpoints(serviceName string, spec HandlerSpec) ([]*EndpointInfo, error) {
 	if spec == nil {
 		return nil, errors.New("reflectEndpoints: spec is nil")
@@ -30,16 +62,24 @@ func reflectEndpoints(serviceName string, spec HandlerSpec) ([]*EndpointInfo, er
 	specVal := reflect.ValueOf(spec)
 	_, handlerName := ifaceToPkgName(specTyp)
 	httpMethods := httpMethodsOf(spec)
+	docs := methodDocsOf(spec)
 
 	n := specTyp.NumMethod()
 	endpoints := make([]*EndpointInfo, 0, n)
 	for i := 0; i < n; i++ {
 		m := specTyp.Method(i)
-		if isHandlerFunc(m.Type) {
-			handlerFn := specVal.MethodByName(m.Name).Interface().(func(c *httpserve.Context) httpserve.Response)
+		var handlerFn func(c *httpserve.Context) httpserve.Response
+		switch {
+		case isHandlerFunc(m.Type):
+			handlerFn = specVal.MethodByName(m.Name).Interface().(func(c *httpserve.Context) httpserve.Response)
+		case isHTTPHandlerFunc(m.Type):
+			handlerFn = adoptHandlerFunc(specVal.MethodByName(m.Name).Interface().(func(http.ResponseWriter, *http.Request)))
+		}
+		if handlerFn != nil {
 			endpoint := &EndpointInfo{
 				Service: serviceName,
 				Path:    fmt.Sprintf("/%s/%s", handlerName, m.Name),
+				Doc:     docs[m.Name],
 				Handler: handlerFn,
 				SpecTyp: specTyp,
 			}
@@ -53,6 +93,16 @@ func reflectEndpoints(serviceName string, spec HandlerSpec) ([]*EndpointInfo, er
 			endpoints = append(endpoints, endpoint)
 		}
 	}
+	if s, ok := spec.(BatchSpec); ok {
+		endpoints = append(endpoints, &EndpointInfo{
+			Service: serviceName,
+			Path:    fmt.Sprintf("/%s/%s", handlerName, BatchMethod),
+			Doc:     "Serves several calls in a single exchange.",
+			Methods: []string{"POST"},
+			Handler: adoptHandlerFunc(s.BatchHandler().ServeHTTP),
+			SpecTyp: specTyp,
+		})
+	}
 	return endpoints, nil
 }
 
@@ -68,13 +118,18 @@ func reflectEndpointInfo(serviceName string, spec HandlerSpec, fnName string) (*
 		return endpoint, nil
 	}
 	specTyp := reflect.TypeOf(spec)
-	if len(fnName) > 0 {
+	if fnName == BatchMethod {
+		if !specTyp.Implements(batchSpecTyp) {
+			err := fmt.Errorf("reflectEndpointInfo: spec doesn't serve batches")
+			return nil, err
+		}
+	} else if len(fnName) > 0 {
 		m, ok := specTyp.MethodByName(fnName)
 		if !ok {
 			err := fmt.Errorf("reflectEndpointInfo: spec doesnt't have method %s", fnName)
 			return nil, err
 		}
-		if !isHandlerFunc(m.Type) {
+		if !isHandlerFunc(m.Type) && !isHTTPHandlerFunc(m.Type) {
 			err := fmt.Errorf("reflectEndpointInfo: method %s is not a http.HandlerFunc", fnName)
 			return nil, err
 		}
@@ -84,6 +139,7 @@ func reflectEndpointInfo(serviceName string, spec HandlerSpec, fnName string) (*
 	endpoint := &EndpointInfo{
 		Service: serviceName,
 		Path:    fmt.Sprintf("/%s/%s", handlerName, fnName),
+		Doc:     methodDocsOf(spec)[fnName],
 		SpecTyp: specTyp,
 	}
 	if methods, ok := httpMethods["*"]; ok {
@@ -99,6 +155,8 @@ func reflectEndpointInfo(serviceName string, spec HandlerSpec, fnName string) (*
 type EndpointInfo struct {
 	Service string
 	Path    string
+	// Doc is the doc comment of the service interface method, if known.
+	Doc     string
 	Methods []string
 	SpecTyp reflect.Type
 	Handler func(c *httpserve.Context) httpserve.Response
@@ -120,15 +178,21 @@ func (e *EndpointInfo) MethodAllowed(method string) bool {
 func (e *EndpointInfo) IsValidHandler(name string) bool {
 	if e.SpecTyp == nil {
 		return true
+	} else if name == BatchMethod {
+		return e.SpecTyp.Implements(batchSpecTyp)
 	}
 	fn, exists := e.SpecTyp.MethodByName(name)
 	if !exists {
 		return false
 	}
-	return isHandlerFunc(fn.Type)
+	return isHandlerFunc(fn.Type) || isHTTPHandlerFunc(fn.Type)
 }
 
-var httpContextTyp = reflect.TypeOf((*httpserve.Context)(nil))
+var (
+	httpContextTyp        = reflect.TypeOf((*httpserve.Context)(nil))
+	httpResponseWriterTyp = reflect.TypeOf((*http.ResponseWriter)(nil)).Elem()
+	httpRequestTyp        = reflect.TypeOf((*http.Request)(nil))
+)
 
 // ifacePkgName returns package path for an interface type, and the type's name.
 func ifaceToPkgName(typ reflect.Type) (pkgName string, typName string) {
@@ -152,3 +216,26 @@ func isHandlerFunc(fn reflect.Type) bool {
 	}
 	return true
 }
+
+// isHTTPHandlerFunc checks method to match the net/http handler func
+// func(http.ResponseWriter, *http.Request)
+func isHTTPHandlerFunc(fn reflect.Type) bool {
+	if fn.NumIn() != 3 {
+		return false
+	}
+	if fn.NumOut() != 0 {
+		return false
+	}
+	if fn.In(1) != httpResponseWriterTyp || fn.In(2) != httpRequestTyp {
+		return false
+	}
+	return true
+}
+
+// adoptHandlerFunc wraps a net/http handler func, so it could be served by the httpserve router.
+func adoptHandlerFunc(fn func(http.ResponseWriter, *http.Request)) func(c *httpserve.Context) httpserve.Response {
+	return func(c *httpserve.Context) httpserve.Response {
+		fn(c.Writer, c.Request)
+		return httpserve.NewAdoptResponse()
+	}
+}
@@ -0,0 +1,111 @@
+package cluster
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/astranet/httpserve"
+)
+
+type testHandler struct{}
+
+func (*testHandler) Serve(c *httpserve.Context) httpserve.Response {
+	return httpserve.NewNoContentResponse()
+}
+
+func (*testHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {}
+
+func (*testHandler) Helper(name string) string {
+	return name
+}
+
+func (*testHandler) HTTPMethodsMap() map[string][]string {
+	return map[string][]string{
+		"ServeHTTP": {"POST"},
+	}
+}
+
+func (*testHandler) MethodDocs() map[string]string {
+	return map[string]string{
+		"Serve": "Serve serves.",
+	}
+}
+
+func TestDescribeEndpoints(t *testing.T) {
+	endpoints, err := DescribeEndpoints("test", &testHandler{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var paths []string
+	for _, e := range endpoints {
+		paths = append(paths, e.Path)
+		if e.Handler == nil {
+			t.Errorf("%s has no handler", e.Path)
+		}
+		if e.Path == "/testHandler/ServeHTTP" && !reflect.DeepEqual(e.Methods, []string{"POST"}) {
+			t.Errorf("%s methods = %v, want [POST]", e.Path, e.Methods)
+		}
+		if e.Path == "/testHandler/Serve" && e.Doc != "Serve serves." {
+			t.Errorf("%s doc = %q, want the one of MethodDocs", e.Path, e.Doc)
+		}
+	}
+	want := []string{"/testHandler/Serve", "/testHandler/ServeHTTP"}
+	if !reflect.DeepEqual(paths, want) {
+		t.Errorf("DescribeEndpoints() paths = %v, want %v", paths, want)
+	}
+}
+
+func TestIsValidHandler(t *testing.T) {
+	e, err := reflectEndpointInfo("test", &testHandler{}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := map[string]bool{
+		"Serve":     true,
+		"ServeHTTP": true,
+		"Helper":    false,
+		"Missing":   false,
+		BatchMethod: false,
+	}
+	for name, want := range tests {
+		if got := e.IsValidHandler(name); got != want {
+			t.Errorf("IsValidHandler(%q) = %v, want %v", name, got, want)
+		}
+	}
+	if e, err := reflectEndpointInfo("test", &testHandler{}, "Serve"); err != nil || e.Doc != "Serve serves." {
+		t.Errorf("reflectEndpointInfo() = %+v, %v, want the doc of Serve", e, err)
+	}
+	if _, err := reflectEndpointInfo("test", &testHandler{}, "Helper"); err == nil {
+		t.Error("reflectEndpointInfo() of a non-handler method succeeded")
+	}
+}
+
+type testBatchHandler struct {
+	testHandler
+}
+
+func (*testBatchHandler) BatchHandler() http.Handler {
+	return http.NotFoundHandler()
+}
+
+func TestBatchEndpoint(t *testing.T) {
+	endpoints, err := DescribeEndpoints("test", &testBatchHandler{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	last := endpoints[len(endpoints)-1]
+	if last.Path != "/testBatchHandler/"+BatchMethod || !reflect.DeepEqual(last.Methods, []string{"POST"}) {
+		t.Errorf("last endpoint = %s %v, want POST /testBatchHandler/%s", last.Path, last.Methods, BatchMethod)
+	}
+	e, err := reflectEndpointInfo("test", &testBatchHandler{}, BatchMethod)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !e.IsValidHandler(BatchMethod) {
+		t.Errorf("IsValidHandler(%q) = false, want true", BatchMethod)
+	}
+	if _, err := reflectEndpointInfo("test", &testHandler{}, BatchMethod); err == nil {
+		t.Error("reflectEndpointInfo() of the batch endpoint of a spec without batches succeeded")
+	}
+}
@@ -0,0 +1,174 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v2"
+)
+
+// configNames are the file names of the project config, in order of precedence.
+var configNames = []string{
+	"meshrpc.toml",
+	"meshrpc.yaml",
+	"meshrpc.yml",
+}
+
+// Config is the project-level generator config, it declares all service interfaces
+// to expose, so they could be regenerated at once with meshRPC generate.
+//
+// Example of meshrpc.toml:
+//
+//	templates = "meshrpc_templates"
+//
+//	[[services]]
+//	src = "greeter/service"
+//	package = "greeter"
+//	client_out = "greeter/greeterclient"
+type Config struct {
+	// Templates is a dir with template overrides, used by all services by default.
+	Templates string `toml:"templates" yaml:"templates"`
+	// Codecs are wire codecs to generate, only "json" is supported.
+	Codecs []string `toml:"codecs" yaml:"codecs"`
+	// Languages are targets to generate code for, only "go" is supported.
+	Languages []string `toml:"languages" yaml:"languages"`
+
+	Services []ServiceConfig `toml:"services" yaml:"services"`
+}
+
+// ServiceConfig declares a single service interface to expose,
+// all paths are relative to the config file.
+type ServiceConfig struct {
+	// Src is a Go source file or a package dir with the service interface.
+	Src string `toml:"src" yaml:"src"`
+	// Package is the name of the package with the service interface.
+	Package string `toml:"package" yaml:"package"`
+	// Prefix is an optional feature prefix, see -M option of expose.
+	Prefix string `toml:"prefix" yaml:"prefix"`
+	// Server is the handler flavour: httpserve (default) or nethttp.
+	Server string `toml:"server" yaml:"server"`
+	// ClientOut is an optional dir of a standalone client package.
+	ClientOut string `toml:"client_out" yaml:"client_out"`
+	// Templates overrides the project-level templates dir.
+	Templates string `toml:"templates" yaml:"templates"`
+	// Codecs override the project-level codecs.
+	Codecs []string `toml:"codecs" yaml:"codecs"`
+	// Languages override the project-level languages.
+	Languages []string `toml:"languages" yaml:"languages"`
+}
+
+var (
+	supportedCodecs    = []string{"json"}
+	supportedLanguages = []string{"go"}
+)
+
+// findConfig looks up the project config in dir and all its parents.
+func findConfig(dir string) (string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	for {
+		for _, name := range configNames {
+			path := filepath.Join(dir, name)
+			if _, err := os.Stat(path); err == nil {
+				return path, nil
+			}
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", fmt.Errorf("none of %v found in the working dir or its parents", configNames)
+		}
+		dir = parent
+	}
+}
+
+// loadConfig reads the project config, either TOML or YAML depending on the extension.
+// Relative paths of the config are resolved against the config dir.
+func loadConfig(path string) (*Config, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	cfg := new(Config)
+	switch filepath.Ext(path) {
+	case ".toml":
+		md, err := toml.Decode(string(data), cfg)
+		if err != nil {
+			return nil, err
+		}
+		if undecoded := md.Undecoded(); len(undecoded) > 0 {
+			return nil, fmt.Errorf("unknown keys: %v", undecoded)
+		}
+	case ".yaml", ".yml":
+		if err := yaml.UnmarshalStrict(data, cfg); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unknown config format: %s", path)
+	}
+	if err := cfg.resolve(filepath.Dir(path)); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+func (c *Config) resolve(dir string) error {
+	if len(c.Services) == 0 {
+		return fmt.Errorf("no services declared")
+	}
+	c.Templates = resolvePath(dir, c.Templates)
+	for i := range c.Services {
+		svc := &c.Services[i]
+		if len(svc.Src) == 0 {
+			return fmt.Errorf("services[%d]: src must be set", i)
+		} else if len(svc.Package) == 0 {
+			return fmt.Errorf("services[%d]: package must be set", i)
+		}
+		svc.Src = resolvePath(dir, svc.Src)
+		svc.ClientOut = resolvePath(dir, svc.ClientOut)
+		svc.Templates = resolvePath(dir, svc.Templates)
+		if len(svc.Templates) == 0 {
+			svc.Templates = c.Templates
+		}
+		if len(svc.Codecs) == 0 {
+			svc.Codecs = c.Codecs
+		}
+		if len(svc.Languages) == 0 {
+			svc.Languages = c.Languages
+		}
+		if err := checkSupported("codec", svc.Codecs, supportedCodecs); err != nil {
+			return fmt.Errorf("services[%d]: %v", i, err)
+		}
+		if err := checkSupported("language", svc.Languages, supportedLanguages); err != nil {
+			return fmt.Errorf("services[%d]: %v", i, err)
+		}
+	}
+	return nil
+}
+
+func resolvePath(dir, path string) string {
+	if len(path) == 0 || filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(dir, path)
+}
+
+func checkSupported(kind string, values, supported []string) error {
+	for _, v := range values {
+		var ok bool
+		for _, s := range supported {
+			if v == s {
+				ok = true
+				break
+			}
+		}
+		if !ok {
+			return fmt.Errorf("%s %q is not supported, expected one of %v", kind, v, supported)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLoadConfig(t *testing.T) {
+	tests := []struct {
+		name     string
+		contents string
+		want     []ServiceConfig
+		err      string
+	}{{
+		name: "meshrpc.toml",
+		contents: `
+templates = "tpl"
+codecs = ["json"]
+
+[[services]]
+src = "greeter/service"
+package = "greeter"
+client_out = "/abs/greeterclient"
+
+[[services]]
+src = "shop"
+package = "shop"
+prefix = "Cart"
+server = "nethttp"
+templates = "shop/tpl"
+`,
+		want: []ServiceConfig{{
+			Src:       "greeter/service",
+			Package:   "greeter",
+			ClientOut: "/abs/greeterclient",
+			Templates: "tpl",
+			Codecs:    []string{"json"},
+		}, {
+			Src:       "shop",
+			Package:   "shop",
+			Prefix:    "Cart",
+			Server:    "nethttp",
+			Templates: "shop/tpl",
+			Codecs:    []string{"json"},
+		}},
+	}, {
+		name: "meshrpc.yaml",
+		contents: `
+services:
+- src: greeter/service
+  package: greeter
+  languages: [go]
+`,
+		want: []ServiceConfig{{
+			Src:       "greeter/service",
+			Package:   "greeter",
+			Languages: []string{"go"},
+		}},
+	}, {
+		name:     "meshrpc.toml",
+		contents: "[[services]]\nsrc = \"a\"\npackage = \"a\"\nclientout = \"b\"\n",
+		err:      "unknown keys",
+	}, {
+		name:     "meshrpc.yml",
+		contents: "services:\n- src: a\n  package: a\n  client: b\n",
+		err:      "field client not found",
+	}, {
+		name:     "meshrpc.toml",
+		contents: "templates = \"tpl\"\n",
+		err:      "no services declared",
+	}, {
+		name:     "meshrpc.toml",
+		contents: "[[services]]\nsrc = \"a\"\n",
+		err:      "services[0]: package must be set",
+	}, {
+		name:     "meshrpc.toml",
+		contents: "[[services]]\nsrc = \"a\"\npackage = \"a\"\ncodecs = [\"protobuf\"]\n",
+		err:      `services[0]: codec "protobuf" is not supported`,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := tempDir(t)
+			defer os.RemoveAll(dir)
+			path := filepath.Join(dir, tt.name)
+			writeFile(t, path, tt.contents, 0644)
+			cfg, err := loadConfig(path)
+			if len(tt.err) > 0 {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("loadConfig() error = %v, want %q", err, tt.err)
+				}
+				return
+			} else if err != nil {
+				t.Fatal(err)
+			}
+			for i := range tt.want {
+				svc := &tt.want[i]
+				svc.Src = resolvePath(dir, svc.Src)
+				svc.ClientOut = resolvePath(dir, svc.ClientOut)
+				svc.Templates = resolvePath(dir, svc.Templates)
+			}
+			if !reflect.DeepEqual(cfg.Services, tt.want) {
+				t.Errorf("loadConfig() services =\n%+v\nwant\n%+v", cfg.Services, tt.want)
+			}
+		})
+	}
+}
+
+func TestFindConfig(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+	nested := filepath.Join(dir, "a", "b")
+	if err := os.MkdirAll(nested, 0755); err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, filepath.Join(dir, "meshrpc.yaml"), "", 0644)
+	writeFile(t, filepath.Join(dir, "a", "meshrpc.toml"), "", 0644)
+	writeFile(t, filepath.Join(dir, "a", "meshrpc.yml"), "", 0644)
+
+	path, err := findConfig(nested)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := filepath.Join(dir, "a", "meshrpc.toml"); path != want {
+		t.Errorf("findConfig() = %s, want %s", path, want)
+	}
+}
@@ -0,0 +1,178 @@
+// Package dedup keeps responses of calls by their idempotency keys, so generated handlers
+// replay them to retries of the calls instead of calling the service again. Clients send
+// the key in the Idempotency-Key header, the same for all attempts of a call.
+package dedup
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/astranet/meshRPC/intercept"
+)
+
+// KeyHeader carries the idempotency key of a call.
+const KeyHeader = "Idempotency-Key"
+
+// ErrKeyReused is returned when a key comes along with another request than the one
+// it has been used for first, it's rejected with 422 Unprocessable Entity.
+var ErrKeyReused = &intercept.StatusError{
+	Status: http.StatusUnprocessableEntity,
+	Err:    errors.New("idempotency key has been used for another request"),
+}
+
+// Cache is a bounded cache of responses by keys, that expire after a TTL since their
+// calls have completed. Keys of calls in progress are never evicted, so the cache may
+// exceed its size while they run. It's safe for concurrent use.
+type Cache struct {
+	size int
+	ttl  time.Duration
+
+	mux     sync.Mutex
+	entries map[string]*list.Element
+	order   *list.List
+}
+
+type entry struct {
+	key     string
+	reqHash [sha256.Size]byte
+	// finished is the time the call has completed, it's zero while the call is in progress
+	// and guarded by the lock of the cache
+	finished time.Time
+	// done is closed once the call completes, resp and err are set by then
+	done   chan struct{}
+	stored bool
+	resp   []byte
+	err    error
+}
+
+// NewCache returns a cache of up to size responses, each kept for ttl.
+// A size below 1 keeps a single response.
+func NewCache(size int, ttl time.Duration) *Cache {
+	if size < 1 {
+		size = 1
+	}
+	return &Cache{
+		size:    size,
+		ttl:     ttl,
+		entries: make(map[string]*list.Element),
+		order:   list.New(),
+	}
+}
+
+// Do makes the call with fn, unless a call with the key has been made already, then
+// its response is copied into resp, along with its error. If the call with the key
+// is still in progress, Do waits for it. The request must be the same as the one
+// the key has been used for first, otherwise ErrKeyReused is returned.
+//
+// Responses of calls that failed with 5xx statuses, or have been cancelled, are not kept,
+// so the next attempt makes the call again.
+func (c *Cache) Do(ctx context.Context, key string, req, resp interface{}, fn func() error) error {
+	reqData, err := json.Marshal(req)
+	if err != nil {
+		return fmt.Errorf("dedup: failed to encode request: %v", err)
+	}
+	reqHash := sha256.Sum256(reqData)
+	for {
+		e, owner := c.acquire(key, reqHash)
+		if e.reqHash != reqHash {
+			return ErrKeyReused
+		}
+		if owner {
+			return c.call(e, resp, fn)
+		}
+		select {
+		case <-e.done:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		if !e.stored {
+			// the call failed in a way that is worth another attempt
+			continue
+		}
+		if len(e.resp) > 0 {
+			if err := json.Unmarshal(e.resp, resp); err != nil {
+				return fmt.Errorf("dedup: failed to decode response: %v", err)
+			}
+		}
+		return e.err
+	}
+}
+
+// acquire returns the entry of the key, it creates one if there is none or it has
+// expired, then the caller owns the entry and must make the call.
+func (c *Cache) acquire(key string, reqHash [sha256.Size]byte) (*entry, bool) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	if el, ok := c.entries[key]; ok {
+		e := el.Value.(*entry)
+		if e.finished.IsZero() || time.Since(e.finished) < c.ttl {
+			return e, false
+		}
+		c.remove(el)
+	}
+	c.evict()
+	e := &entry{
+		key:     key,
+		reqHash: reqHash,
+		done:    make(chan struct{}),
+	}
+	c.entries[key] = c.order.PushBack(e)
+	return e, true
+}
+
+// call makes the call of the entry the caller owns. Waiters are released even if fn
+// panics, then the entry is dropped as if the call failed, and the panic goes on.
+func (c *Cache) call(e *entry, resp interface{}, fn func() error) error {
+	defer func() {
+		c.mux.Lock()
+		if e.stored {
+			e.finished = time.Now()
+		} else if el, ok := c.entries[e.key]; ok && el.Value == e {
+			c.remove(el)
+		}
+		c.mux.Unlock()
+		close(e.done)
+	}()
+	err := fn()
+	status := intercept.Status(err)
+	if err == nil || (status < 500 && status != intercept.StatusClientClosedRequest) {
+		e.err = err
+		if err == nil {
+			e.resp, _ = json.Marshal(resp)
+		}
+		e.stored = true
+	}
+	return err
+}
+
+// evict drops the oldest completed calls to make room for a new one, calls in progress
+// are kept. The caller must hold the lock.
+func (c *Cache) evict() {
+	for el := c.order.Front(); el != nil && c.order.Len() >= c.size; {
+		next := el.Next()
+		if !el.Value.(*entry).finished.IsZero() {
+			c.remove(el)
+		}
+		el = next
+	}
+}
+
+// remove drops the entry, the caller must hold the lock.
+func (c *Cache) remove(el *list.Element) {
+	delete(c.entries, el.Value.(*entry).key)
+	c.order.Remove(el)
+}
+
+// Len returns the number of kept keys.
+func (c *Cache) Len() int {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	return c.order.Len()
+}
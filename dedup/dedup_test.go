@@ -0,0 +1,292 @@
+package dedup
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/astranet/meshRPC/intercept"
+)
+
+type greetRequest struct {
+	Name string `json:"name"`
+}
+
+type greetResponse struct {
+	Message string `json:"message"`
+}
+
+func TestReplay(t *testing.T) {
+	c := NewCache(10, time.Minute)
+	var calls int
+	greet := func(resp *greetResponse) func() error {
+		return func() error {
+			calls++
+			resp.Message = "Hello, John"
+			return nil
+		}
+	}
+	for i := 0; i < 2; i++ {
+		var resp greetResponse
+		err := c.Do(context.Background(), "key", &greetRequest{"John"}, &resp, greet(&resp))
+		if err != nil || resp.Message != "Hello, John" {
+			t.Errorf("Do() #%d = %q, %v, want a greeting", i+1, resp.Message, err)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("%d calls, want the response of the first one replayed", calls)
+	}
+
+	badRequest := errors.New("no name")
+	for i := 0; i < 2; i++ {
+		err := c.Do(context.Background(), "bad", &greetRequest{}, &greetResponse{}, func() error {
+			calls++
+			return badRequest
+		})
+		if err != badRequest {
+			t.Errorf("Do() #%d = %v, want %v", i+1, err, badRequest)
+		}
+	}
+	if calls != 2 {
+		t.Errorf("%d calls, want the error of a 4xx call replayed", calls)
+	}
+}
+
+func TestKeyReused(t *testing.T) {
+	c := NewCache(10, time.Minute)
+	noop := func() error { return nil }
+	c.Do(context.Background(), "key", &greetRequest{"John"}, &greetResponse{}, noop)
+	err := c.Do(context.Background(), "key", &greetRequest{"Jane"}, &greetResponse{}, noop)
+	if err != ErrKeyReused || intercept.Status(err) != http.StatusUnprocessableEntity {
+		t.Errorf("Do() with another request = %v, want %v with 422", err, ErrKeyReused)
+	}
+}
+
+func TestNotStored(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+	}{
+		{"5xx", &intercept.StatusError{Status: http.StatusServiceUnavailable, Err: errors.New("unavailable")}},
+		{"timeout", context.DeadlineExceeded},
+		{"cancelled", context.Canceled},
+	}
+	for _, tt := range tests {
+		c := NewCache(10, time.Minute)
+		var calls int
+		for i := 0; i < 2; i++ {
+			c.Do(context.Background(), "key", &greetRequest{"John"}, &greetResponse{}, func() error {
+				calls++
+				return tt.err
+			})
+		}
+		if calls != 2 || c.Len() != 0 {
+			t.Errorf("%s: %d calls, %d keys kept, want 2 calls and none kept", tt.name, calls, c.Len())
+		}
+	}
+}
+
+func TestConcurrentCalls(t *testing.T) {
+	c := NewCache(10, time.Minute)
+	var (
+		calls   int
+		started = make(chan struct{})
+		release = make(chan struct{})
+	)
+	call := func(resp *greetResponse) error {
+		return c.Do(context.Background(), "key", &greetRequest{"John"}, resp, func() error {
+			calls++
+			close(started)
+			<-release
+			resp.Message = "Hello, John"
+			return nil
+		})
+	}
+	var owner greetResponse
+	go call(&owner)
+	<-started
+
+	var wg sync.WaitGroup
+	resps := make([]greetResponse, 4)
+	errs := make([]error, len(resps))
+	for i := range resps {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = call(&resps[i])
+		}(i)
+	}
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	for i := range resps {
+		if errs[i] != nil || resps[i].Message != "Hello, John" {
+			t.Errorf("Do() of a waiter = %q, %v, want the response of the call", resps[i].Message, errs[i])
+		}
+	}
+	if calls != 1 {
+		t.Errorf("%d calls, want waiters to get the response of the call in flight", calls)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	blocked := make(chan struct{})
+	defer close(blocked)
+	go c.Do(context.Background(), "slow", &greetRequest{"John"}, &greetResponse{}, func() error {
+		<-blocked
+		return nil
+	})
+	time.Sleep(10 * time.Millisecond)
+	if err := c.Do(ctx, "slow", &greetRequest{"John"}, &greetResponse{}, nil); err != context.Canceled {
+		t.Errorf("Do() of a waiter that has gone away = %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestPanic(t *testing.T) {
+	c := NewCache(10, time.Minute)
+	started := make(chan struct{})
+	release := make(chan struct{})
+	panicked := make(chan interface{})
+	go func() {
+		defer func() {
+			panicked <- recover()
+		}()
+		c.Do(context.Background(), "key", &greetRequest{"John"}, &greetResponse{}, func() error {
+			close(started)
+			<-release
+			panic("boom")
+		})
+	}()
+	<-started
+
+	waiter := make(chan error)
+	go func() {
+		waiter <- c.Do(context.Background(), "key", &greetRequest{"John"}, &greetResponse{}, func() error {
+			return nil
+		})
+	}()
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	if v := <-panicked; v != "boom" {
+		t.Errorf("recovered %v, want the panic of the call", v)
+	}
+	select {
+	case err := <-waiter:
+		if err != nil {
+			t.Errorf("Do() of a waiter = %v, want the call made again", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("waiter hangs after the call has panicked")
+	}
+}
+
+func TestSize(t *testing.T) {
+	c := NewCache(0, time.Minute)
+	noop := func() error { return nil }
+	for _, key := range []string{"a", "b", "c"} {
+		if err := c.Do(context.Background(), key, &greetRequest{"John"}, &greetResponse{}, noop); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if c.Len() != 1 {
+		t.Errorf("Len() = %d, want the last key only", c.Len())
+	}
+}
+
+func TestEvictRunning(t *testing.T) {
+	c := NewCache(1, time.Minute)
+	var calls int
+	started := make(chan struct{})
+	release := make(chan struct{})
+	owner := make(chan error)
+	go func() {
+		owner <- c.Do(context.Background(), "slow", &greetRequest{"John"}, &greetResponse{}, func() error {
+			calls++
+			close(started)
+			<-release
+			return nil
+		})
+	}()
+	<-started
+
+	noop := func() error { return nil }
+	if err := c.Do(context.Background(), "fast", &greetRequest{"John"}, &greetResponse{}, noop); err != nil {
+		t.Fatal(err)
+	}
+	if c.Len() != 2 {
+		t.Errorf("Len() = %d, want the call in progress kept over the size", c.Len())
+	}
+	retry := make(chan error)
+	go func() {
+		retry <- c.Do(context.Background(), "slow", &greetRequest{"John"}, &greetResponse{}, func() error {
+			calls++
+			return nil
+		})
+	}()
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	if err := <-owner; err != nil {
+		t.Fatal(err)
+	}
+	if err := <-retry; err != nil {
+		t.Fatal(err)
+	}
+	if calls != 1 {
+		t.Errorf("%d calls of the slow key, want the retry to wait for the call in progress", calls)
+	}
+	c.Do(context.Background(), "next", &greetRequest{"John"}, &greetResponse{}, noop)
+	if c.Len() != 1 {
+		t.Errorf("Len() = %d, want completed calls evicted down to the size", c.Len())
+	}
+}
+
+func TestTTLOfRunning(t *testing.T) {
+	ttl := 20 * time.Millisecond
+	c := NewCache(10, ttl)
+	var calls int
+	started := make(chan struct{})
+	owner := make(chan error)
+	go func() {
+		owner <- c.Do(context.Background(), "key", &greetRequest{"John"}, &greetResponse{}, func() error {
+			calls++
+			close(started)
+			time.Sleep(3 * ttl)
+			return nil
+		})
+	}()
+	<-started
+	time.Sleep(2 * ttl)
+	err := c.Do(context.Background(), "key", &greetRequest{"John"}, &greetResponse{}, func() error {
+		calls++
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := <-owner; err != nil {
+		t.Fatal(err)
+	}
+	if calls != 1 {
+		t.Errorf("%d calls, want a call longer than the TTL made once", calls)
+	}
+
+	// the TTL starts once the call has completed
+	c.Do(context.Background(), "key", &greetRequest{"John"}, &greetResponse{}, func() error {
+		calls++
+		return nil
+	})
+	if calls != 1 {
+		t.Errorf("%d calls, want the response replayed within the TTL after the call", calls)
+	}
+	time.Sleep(2 * ttl)
+	c.Do(context.Background(), "key", &greetRequest{"John"}, &greetResponse{}, func() error {
+		calls++
+		return nil
+	})
+	if calls != 2 {
+		t.Errorf("%d calls, want the key expired after the TTL", calls)
+	}
+}
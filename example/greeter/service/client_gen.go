@@ -5,25 +5,293 @@ package greeter
 
 import (
 	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"io/ioutil"
 	"net/http"
+	"strings"
+	"time"
 
-	"github.com/astranet/httpserve"
-	"github.com/pkg/errors"
+	"github.com/astranet/meshRPC/intercept"
+	"github.com/astranet/meshRPC/retry"
+	"github.com/astranet/meshRPC/trace"
 )
 
+// ServiceClient extends Service with context-aware method variants,
+// that accept call options, e.g. CallTimeout, and asynchronous ones that return futures.
 type ServiceClient interface {
 	Service
+
+	// GreetContext is Greet with a context and call options.
+	GreetContext(_ctx context.Context, name string, _opts ...CallOption) (message string, _err error)
+	// GreetAsync sends the Greet call in the background.
+	GreetAsync(_ctx context.Context, name string, _opts ...CallOption) *GreetFuture
+	// SendPostcardContext is SendPostcard with a context and call options.
+	SendPostcardContext(_ctx context.Context, card *Postcard, _opts ...CallOption) (_err error)
+	// SendPostcardAsync sends the SendPostcard call in the background.
+	SendPostcardAsync(_ctx context.Context, card *Postcard, _opts ...CallOption) *SendPostcardFuture
+
+	// Batch returns a builder of calls that are sent to the service in a single request.
+	Batch() *Batch
+	// Outbox returns a builder of calls that are queued in the outbox of the client options.
+	Outbox() *Outbox
+}
+
+// Future is implemented by futures returned by Async methods of ServiceClient.
+type Future interface {
+	// Done returns a channel that is closed when the call completes.
+	Done() <-chan struct{}
+	// Err blocks until the call completes and returns its error, if any.
+	Err() error
+}
+
+// WaitAll blocks until all futures complete or the context is done. It returns
+// the first error of the calls in order of futures, or the context error.
+func WaitAll(ctx context.Context, futures ...Future) error {
+	for _, f := range futures {
+		select {
+		case <-f.Done():
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	for _, f := range futures {
+		if err := f.Err(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Batch collects calls that are sent to the service in a single request by Send.
+// Methods of the batch add calls and return futures, that complete once the batch is sent.
+type Batch struct {
+	client  *rpcClient
+	calls   []rpcClientBatchCall
+	resolve []func(data json.RawMessage, err error)
+}
+
+type rpcClientBatchCall struct {
+	Method string      `json:"method"`
+	Params interface{} `json:"params,omitempty"`
+}
+
+type rpcClientBatchResult struct {
+	Status int             `json:"status"`
+	Data   json.RawMessage `json:"data"`
+	Errors []string        `json:"errors"`
+}
+
+func (r *rpcClientBatchResult) err() error {
+	if r.Status >= 200 && r.Status <= 299 {
+		return nil
+	}
+	return &intercept.StatusError{
+		Status: r.Status,
+		Err:    fmt.Errorf("service error %d: %s", r.Status, strings.Join(r.Errors, "; ")),
+	}
+}
+
+// Send sends all calls of the batch in a single request, call options apply to the request
+// as a whole, and so do interceptors, that see it as a call of the "__batch__" method.
+// The request is retried by the retry policy if all calls are idempotent. An error is
+// returned if the request fails, then all futures fail with it too, errors of single
+// calls are only reported by their futures. Send resets the batch, so it may be reused
+// for other calls.
+func (_b *Batch) Send(ctx context.Context, opts ...CallOption) error {
+	calls, resolve := _b.calls, _b.resolve
+	_b.calls, _b.resolve = nil, nil
+	if len(calls) == 0 {
+		return nil
+	}
+	idempotent := true
+	for _, call := range calls {
+		idempotent = idempotent && rpcClientIdempotent[call.Method]
+	}
+	if idempotent {
+		// a batch of idempotent calls is safe to repeat too
+		opts = append([]CallOption{rpcClientCallIdempotent}, opts...)
+	}
+	var results []rpcClientBatchResult
+	err := _b.client.invoke(ctx, "__batch__", calls, &results, opts)
+	if err == nil && len(results) != len(calls) {
+		err = fmt.Errorf("rpcClient: got %d results for a batch of %d calls", len(results), len(calls))
+	}
+	for i, fn := range resolve {
+		if err != nil {
+			fn(nil, err)
+			continue
+		}
+		fn(results[i].Data, results[i].err())
+	}
+	return err
+}
+
+// Outbox queues calls in the outbox of the client options, which delivers them
+// in the background and retries until the service accepts them. Only methods that return
+// no results apart from an error may be queued, errors of delivered calls are not reported back.
+type Outbox struct {
+	client *rpcClient
+}
+
+// OutboxQueue persists calls, so they are delivered even if the process restarts,
+// e.g. *outbox.Outbox of github.com/astranet/meshRPC/outbox.
+type OutboxQueue interface {
+	Enqueue(fnName string, header http.Header, body []byte) error
+}
+
+func (_o *Outbox) enqueue(fnName string, v interface{}) error {
+	queue := _o.client.opt.Outbox
+	if queue == nil {
+		return errors.New("rpcClient: outbox is not configured")
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		err = fmt.Errorf("rpcClient: failed to encode request: %v", err)
+		return err
+	}
+	header := make(http.Header)
+	header.Set("X-MeshRPC-Fingerprint", ServiceClientFingerprint)
+	// the outbox delivers calls at least once, so the service needs a key to tell redeliveries
+	header.Set("Idempotency-Key", rpcClientNewIdempotencyKey())
+	return queue.Enqueue(fnName, header, data)
+}
+
+// CallOption configures a single call of ServiceClient.
+type CallOption func(opt *rpcClientCallOptions)
+
+type rpcClientCallOptions struct {
+	timeout    time.Duration
+	header     http.Header
+	target     string
+	retries    int
+	setRetries bool
+	idempotent bool
+	key        string
+}
+
+// CallTimeout limits the duration of the call, including retries.
+func CallTimeout(timeout time.Duration) CallOption {
+	return func(opt *rpcClientCallOptions) {
+		opt.timeout = timeout
+	}
+}
+
+// CallHeader adds an extra header to the call request.
+func CallHeader(key, value string) CallOption {
+	return func(opt *rpcClientCallOptions) {
+		if opt.header == nil {
+			opt.header = make(http.Header)
+		}
+		opt.header.Add(key, value)
+	}
+}
+
+// CallTarget sends the call to the specific instance of the service,
+// instead of a load-balanced one. See cluster.TargetHeader.
+func CallTarget(instance string) CallOption {
+	return func(opt *rpcClientCallOptions) {
+		opt.target = instance
+	}
+}
+
+// CallRetries overrides the number of retries of the call, otherwise set by the retry
+// policy of the client for idempotent methods and calls with an idempotency key, and zero for others.
+// The call is retried upon errors listed by the policy, with its backoff. Retried calls get an
+// idempotency key, unless they have one, so the service doesn't make them twice.
+func CallRetries(retries int) CallOption {
+	return func(opt *rpcClientCallOptions) {
+		opt.retries = retries
+		opt.setRetries = true
+	}
+}
+
+// CallIdempotencyKey sends the call with the idempotency key, the handler of the service
+// replays the response of the call with the same key instead of making it again, as long as it keeps
+// the key. Calls with a key are retried by the retry policy of the client, like idempotent ones.
+// The key must be unique per call, e.g. the ID of the operation the call is made for, so that
+// the call is not repeated even if the client restarts.
+func CallIdempotencyKey(key string) CallOption {
+	return func(opt *rpcClientCallOptions) {
+		opt.key = key
+	}
+}
+
+// rpcClientNewIdempotencyKey returns a random idempotency key.
+func rpcClientNewIdempotencyKey() string {
+	key := make([]byte, 16)
+	rand.Read(key)
+	return hex.EncodeToString(key)
+}
+
+// rpcClientIdempotent lists methods marked with //meshrpc:idempotent,
+// their calls are retried by the retry policy of the client.
+var rpcClientIdempotent = map[string]bool{
+	"Greet": true,
+}
+
+// rpcClientCallIdempotent marks a call as safe to repeat.
+func rpcClientCallIdempotent(opt *rpcClientCallOptions) {
+	opt.idempotent = true
+}
+
+// ServiceClientFingerprint is a hash of the Service wire format
+// this client has been generated for. It is sent along each call, so handlers are able to
+// detect clients generated from another version of the service interface.
+const ServiceClientFingerprint = "d1ef57617a84e055"
+
+// ServiceClientHandlerName is the name of the remote RPC handler, it may be used
+// as a cluster.HandlerSpec where the package with the handler is not imported.
+const ServiceClientHandlerName = "rpcHandler"
+
+// ErrFingerprintMismatch is the cause of errors returned when the remote service
+// has been generated from another version of the service interface than this client.
+var ErrFingerprintMismatch = errors.New("interface fingerprint mismatch")
+
+// rpcClientFingerprintError wraps ErrFingerprintMismatch with both fingerprints.
+type rpcClientFingerprintError struct {
+	client  string
+	service string
+}
+
+func (e *rpcClientFingerprintError) Error() string {
+	return fmt.Sprintf("client %s, service %s: %v", e.client, e.service, ErrFingerprintMismatch)
+}
+
+// Cause returns ErrFingerprintMismatch, compatible with github.com/pkg/errors.
+func (e *rpcClientFingerprintError) Cause() error {
+	return ErrFingerprintMismatch
+}
+
+// Unwrap returns ErrFingerprintMismatch, compatible with errors.Is.
+func (e *rpcClientFingerprintError) Unwrap() error {
+	return ErrFingerprintMismatch
 }
 
 type ServiceClientOptions struct {
+	// Interceptors wrap calls made by the client, the first one is the outermost.
+	// Interceptors may add headers to send to the call.
+	Interceptors []intercept.Interceptor
+	// Outbox queues calls made with the Outbox builder of the client,
+	// it must deliver them to the same service.
+	Outbox OutboxQueue
+	// RetryPolicy retries calls of idempotent methods, marked with //meshrpc:idempotent,
+	// retry.DefaultPolicy is used if not set. Calls of other methods are only retried
+	// with CallRetries, by the backoff and errors of the policy.
+	RetryPolicy *retry.Policy
 }
 
 func checkServiceClientOptions(opt *ServiceClientOptions) *ServiceClientOptions {
 	if opt == nil {
 		opt = &ServiceClientOptions{}
 	}
+	if opt.RetryPolicy == nil {
+		opt.RetryPolicy = retry.DefaultPolicy
+	}
 	return opt
 }
 
@@ -46,71 +314,315 @@ type rpcClient struct {
 	httpClient HTTPClient
 }
 
+// Batch returns a builder of calls that are sent to the service in a single request.
+func (_client *rpcClient) Batch() *Batch {
+	return &Batch{
+		client: _client,
+	}
+}
+
+// Outbox returns a builder of calls that are queued in the outbox of the client options.
+func (_client *rpcClient) Outbox() *Outbox {
+	return &Outbox{
+		client: _client,
+	}
+}
+
+// Greet returns a greeting message for the person.
 func (_client *rpcClient) Greet(name string) (message string, _err error) {
-	// TODO: Report Stats + Timing
+	return _client.GreetContext(context.Background(), name)
+}
 
+// GreetContext is Greet with a context and call options.
+func (_client *rpcClient) GreetContext(_ctx context.Context, name string, _opts ...CallOption) (message string, _err error) {
 	_req := &GreetRequest{
 		Name: name,
 	}
-	var _resp *GreetResponse
-	var _respBody []byte
-	_respBody, _err = _client.do(_client.newJsonReq("POST", "Greet", _req))
-	if _err != nil {
-		return
-	} else if _err = httpserve.UnmarshalJSONValue(_respBody, &_resp); _err != nil {
+	var _resp GreetResponse
+	if _err = _client.invoke(_ctx, "Greet", _req, &_resp, _opts); _err != nil {
 		return
 	}
 	message = _resp.Message
 	return
 }
 
+// GreetAsync sends the Greet call in the background, results are available from the future.
+func (_client *rpcClient) GreetAsync(_ctx context.Context, name string, _opts ...CallOption) *GreetFuture {
+	_f := &GreetFuture{
+		_done: make(chan struct{}),
+	}
+	go func() {
+		defer close(_f._done)
+		_f.message, _f._err = _client.GreetContext(_ctx, name, _opts...)
+	}()
+	return _f
+}
+
+// GreetFuture is the pending result of GreetAsync.
+type GreetFuture struct {
+	_done   chan struct{}
+	message string
+	_err    error
+}
+
+// Done returns a channel that is closed when the call completes.
+func (_f *GreetFuture) Done() <-chan struct{} {
+	return _f._done
+}
+
+// Wait blocks until the call completes and returns its results.
+func (_f *GreetFuture) Wait() (message string, _err error) {
+	<-_f._done
+	return _f.message, _f._err
+}
+
+// Err blocks until the call completes and returns its error, if any.
+func (_f *GreetFuture) Err() error {
+	<-_f._done
+	return _f._err
+}
+
+// Greet adds the Greet call to the batch, results are available from the future once the batch is sent.
+func (_b *Batch) Greet(name string) *GreetFuture {
+	_f := &GreetFuture{
+		_done: make(chan struct{}),
+	}
+	_req := &GreetRequest{
+		Name: name,
+	}
+	_b.calls = append(_b.calls, rpcClientBatchCall{
+		Method: "Greet",
+		Params: _req,
+	})
+	_b.resolve = append(_b.resolve, func(_data json.RawMessage, _err error) {
+		defer close(_f._done)
+		var _resp GreetResponse
+		if _err == nil && len(_data) > 0 {
+			_err = json.Unmarshal(_data, &_resp)
+		}
+		if _err != nil {
+			_f._err = _err
+			return
+		}
+		_f.message = _resp.Message
+	})
+	return _f
+}
+
+// SendPostcard sends the postcard to its recipient,
+// the recipient must be set.
 func (_client *rpcClient) SendPostcard(card *Postcard) (_err error) {
-	// TODO: Report Stats + Timing
+	return _client.SendPostcardContext(context.Background(), card)
+}
 
+// SendPostcardContext is SendPostcard with a context and call options.
+func (_client *rpcClient) SendPostcardContext(_ctx context.Context, card *Postcard, _opts ...CallOption) (_err error) {
 	_req := &SendPostcardRequest{
 		Card: card,
 	}
-	var _resp *SendPostcardResponse
-	var _respBody []byte
-	_respBody, _err = _client.do(_client.newJsonReq("POST", "SendPostcard", _req))
-	if _err != nil {
-		return
-	} else if _err = httpserve.UnmarshalJSONValue(_respBody, &_resp); _err != nil {
+	var _resp SendPostcardResponse
+	if _err = _client.invoke(_ctx, "SendPostcard", _req, &_resp, _opts); _err != nil {
 		return
 	}
 	return
 }
 
-func (_client *rpcClient) do(req *http.Request) ([]byte, error) {
-	resp, err := _client.httpClient.Do(req)
+// SendPostcardAsync sends the SendPostcard call in the background, results are available from the future.
+func (_client *rpcClient) SendPostcardAsync(_ctx context.Context, card *Postcard, _opts ...CallOption) *SendPostcardFuture {
+	_f := &SendPostcardFuture{
+		_done: make(chan struct{}),
+	}
+	go func() {
+		defer close(_f._done)
+		_f._err = _client.SendPostcardContext(_ctx, card, _opts...)
+	}()
+	return _f
+}
+
+// SendPostcardFuture is the pending result of SendPostcardAsync.
+type SendPostcardFuture struct {
+	_done chan struct{}
+	_err  error
+}
+
+// Done returns a channel that is closed when the call completes.
+func (_f *SendPostcardFuture) Done() <-chan struct{} {
+	return _f._done
+}
+
+// Wait blocks until the call completes and returns its results.
+func (_f *SendPostcardFuture) Wait() (_err error) {
+	<-_f._done
+	return _f._err
+}
+
+// Err blocks until the call completes and returns its error, if any.
+func (_f *SendPostcardFuture) Err() error {
+	<-_f._done
+	return _f._err
+}
+
+// SendPostcard adds the SendPostcard call to the batch, results are available from the future once the batch is sent.
+func (_b *Batch) SendPostcard(card *Postcard) *SendPostcardFuture {
+	_f := &SendPostcardFuture{
+		_done: make(chan struct{}),
+	}
+	_req := &SendPostcardRequest{
+		Card: card,
+	}
+	_b.calls = append(_b.calls, rpcClientBatchCall{
+		Method: "SendPostcard",
+		Params: _req,
+	})
+	_b.resolve = append(_b.resolve, func(_data json.RawMessage, _err error) {
+		defer close(_f._done)
+		var _resp SendPostcardResponse
+		if _err == nil && len(_data) > 0 {
+			_err = json.Unmarshal(_data, &_resp)
+		}
+		if _err != nil {
+			_f._err = _err
+			return
+		}
+	})
+	return _f
+}
+
+// SendPostcard queues the SendPostcard call, it returns once the call is persisted.
+func (_o *Outbox) SendPostcard(card *Postcard) error {
+	return _o.enqueue("SendPostcard", &SendPostcardRequest{
+		Card: card,
+	})
+}
+
+// invoke starts a span of the call, as a child of the span of ctx if there's one,
+// and passes the call through interceptors of the client options, then sends it and
+// decodes the response into resp.
+func (_client *rpcClient) invoke(ctx context.Context, fnName string, req, resp interface{}, opts []CallOption) (err error) {
+	ctx, span := trace.Start(ctx, "greeter.Service/"+fnName, trace.KindClient, nil)
+	defer func() {
+		var statusErr *intercept.StatusError
+		if errors.As(err, &statusErr) {
+			span.SetStatus(statusErr.Status)
+		}
+		span.Finish(err)
+	}()
+	call := &intercept.Call{
+		Service:  "greeter.Service",
+		Method:   fnName,
+		Request:  req,
+		Response: resp,
+		Header:   make(http.Header),
+	}
+	span.Inject(call.Header)
+	return intercept.Invoke(ctx, _client.opt.Interceptors, call, func(ctx context.Context, call *intercept.Call) error {
+		respBody, err := _client.call(ctx, call.Method, call.Request, call.Header, opts)
+		if err != nil {
+			return err
+		}
+		return _client.unmarshalJSONValue(respBody, call.Response)
+	})
+}
+
+// call sends the JSON-encoded request to the fnName method, retrying it by the retry policy
+// if the method is idempotent or the call has an idempotency key, or as many times as call
+// options allow. Attempts of a call share its idempotency key, a random one if not set.
+func (_client *rpcClient) call(ctx context.Context, fnName string, v interface{}, header http.Header, opts []CallOption) ([]byte, error) {
+	var callOpt rpcClientCallOptions
+	for _, o := range opts {
+		o(&callOpt)
+	}
+	if callOpt.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, callOpt.timeout)
+		defer cancel()
+	}
+	data, err := json.Marshal(v)
 	if err != nil {
-		err = errors.Errorf("rpcClient: %v", err)
+		err = fmt.Errorf("rpcClient: failed to encode request: %v", err)
 		return nil, err
 	}
+	policy := _client.opt.RetryPolicy
+	switch {
+	case callOpt.setRetries:
+		policy = policy.WithAttempts(callOpt.retries + 1)
+	case !callOpt.idempotent && len(callOpt.key) == 0 && !rpcClientIdempotent[fnName]:
+		policy = policy.WithAttempts(1)
+	}
+	key := callOpt.key
+	if len(key) == 0 && policy.MaxAttempts > 1 && len(header.Get("Idempotency-Key")) == 0 {
+		key = rpcClientNewIdempotencyKey()
+	}
+	var (
+		respBody []byte
+		status   int
+	)
+	err = policy.Do(ctx, func() (int, error) {
+		req, _ := http.NewRequest("POST", fnName, bytes.NewReader(data))
+		req = req.WithContext(ctx)
+		for key, values := range header {
+			req.Header[key] = append(req.Header[key], values...)
+		}
+		for key, values := range callOpt.header {
+			req.Header[key] = append(req.Header[key], values...)
+		}
+		req.Header.Set("X-MeshRPC-Fingerprint", ServiceClientFingerprint)
+		if deadline, ok := ctx.Deadline(); ok {
+			// the service derives the deadline of the call from the remaining time
+			req.Header.Set("X-MeshRPC-Timeout", time.Until(deadline).String())
+		}
+		if len(callOpt.target) > 0 {
+			req.Header.Set("X-MeshRPC-Target", callOpt.target)
+		}
+		if len(key) > 0 {
+			req.Header.Set("Idempotency-Key", key)
+		}
+		var err error
+		respBody, status, err = _client.do(req)
+		return status, err
+	})
+	if err != nil && status == 0 {
+		// transport errors are passed to the retry policy as is
+		err = fmt.Errorf("rpcClient: %v", err)
+	}
+	return respBody, err
+}
+
+// do sends the request, the zero status stands for transport errors.
+func (_client *rpcClient) do(req *http.Request) ([]byte, int, error) {
+	resp, err := _client.httpClient.Do(req)
+	if err != nil {
+		return nil, 0, err
+	}
 	respBody, _ := ioutil.ReadAll(resp.Body)
 	_ = resp.Body.Close()
-	if resp.StatusCode != http.StatusOK {
+	if fingerprint := resp.Header.Get("X-MeshRPC-Fingerprint"); resp.StatusCode == http.StatusPreconditionFailed &&
+		len(fingerprint) > 0 && fingerprint != ServiceClientFingerprint {
+		err := &rpcClientFingerprintError{
+			client:  ServiceClientFingerprint,
+			service: fingerprint,
+		}
+		return nil, resp.StatusCode, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		err := &intercept.StatusError{
+			Status: resp.StatusCode,
+			Err:    fmt.Errorf("service error %d: %s", resp.StatusCode, resp.Status),
+		}
 		if len(respBody) > 0 {
-			err := errors.Errorf("service error %d: %s", resp.StatusCode, string(respBody))
-			return nil, err
+			err.Err = fmt.Errorf("service error %d: %s", resp.StatusCode, string(respBody))
 		}
-		err := errors.Errorf("service error %d: %s", resp.StatusCode, resp.Status)
-		return nil, err
+		return nil, resp.StatusCode, err
 	}
-	return respBody, nil
-}
-
-func (_client *rpcClient) newJsonReq(method string, fnName string, v interface{}) *http.Request {
-	data, _ := json.Marshal(v)
-	req, _ := http.NewRequest(method, fnName, bytes.NewReader(data))
-	return req
+	return respBody, resp.StatusCode, nil
 }
 
-func (_client *rpcClient) checkJsonRespErr(resp []byte) error {
-	var e ErrorResponse
-	_ = json.Unmarshal(resp, &e)
-	if len(e.Error) == 0 {
-		return nil
+// unmarshalJSONValue decodes the data field of a JSON response envelope into v.
+func (_client *rpcClient) unmarshalJSONValue(data []byte, v interface{}) error {
+	value := struct {
+		Data interface{} `json:"data"`
+	}{
+		Data: v,
 	}
-	return errors.New(e.Error)
+	return json.Unmarshal(data, &value)
 }
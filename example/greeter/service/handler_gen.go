@@ -4,19 +4,58 @@
 package greeter
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/astranet/httpserve"
+	"github.com/astranet/meshRPC/dedup"
+	"github.com/astranet/meshRPC/intercept"
+	"github.com/astranet/meshRPC/trace"
+	"github.com/astranet/meshRPC/validate"
 )
 
 type RPCHandler interface {
 	Greet(*httpserve.Context) httpserve.Response
 	SendPostcard(*httpserve.Context) httpserve.Response
+
+	// FingerprintMismatches returns the number of calls that have been served
+	// in permissive mode for clients with a mismatching fingerprint.
+	FingerprintMismatches() uint64
+	// BatchHandler returns the handler of requests that carry several calls.
+	BatchHandler() http.Handler
 }
 
 var RPCHandlerSpec RPCHandler = &rpcHandler{}
 
+// RPCHandlerFingerprint is a hash of the Service wire format
+// this handler has been generated for. Clients send their own fingerprint along each call.
+const RPCHandlerFingerprint = "d1ef57617a84e055"
+
 type RPCHandlerOptions struct {
+	// PermissiveFingerprint allows to serve clients that have been generated for
+	// another version of the service interface, mismatches are logged and counted.
+	// By default such calls are rejected with 412 Precondition Failed.
+	PermissiveFingerprint bool
+	// Interceptors wrap calls of the service methods, the first one is the outermost.
+	// Calls of a batch are intercepted one by one.
+	Interceptors []intercept.Interceptor
+	// MaxBatchCalls limits the number of calls in a batch request,
+	// rpcHandlerMaxBatchCalls by default.
+	MaxBatchCalls int
+	// IdempotencyKeys is the number of recent idempotency keys, along with responses of their
+	// calls, the handler keeps to replay them to retries, rpcHandlerIdempotencyKeys
+	// by default. A negative number disables replays.
+	IdempotencyKeys int
+	// IdempotencyTTL is the time the handler keeps a key for, rpcHandlerIdempotencyTTL
+	// by default.
+	IdempotencyTTL time.Duration
 }
 
 func checkRPCHandlerOptions(opt *RPCHandlerOptions) *RPCHandlerOptions {
@@ -37,37 +76,54 @@ func NewRPCHandler(
 }
 
 type rpcHandler struct {
+	// accessed atomically, must be 64-bit aligned
+	fingerprintMismatches uint64
+
 	svc Service
 	opt *RPCHandlerOptions
+
+	dedupOnce sync.Once
+	dedup     *dedup.Cache
 }
 
+// GreetRequest holds params of Greet.
+//
+// Greet returns a greeting message for the person.
 type GreetRequest struct {
-	Name string `json:"name,omitempty"`
+	// name of the person to greet
+	Name string `json:"name,omitempty" validate:"required,max=64"`
 }
 
+// GreetResponse holds results of Greet.
+//
+// Greet returns a greeting message for the person.
 type GreetResponse struct {
 	Message string `json:"message,omitempty"`
 }
 
 func (_handler *rpcHandler) Greet(_ctx *httpserve.Context) (_res httpserve.Response) {
-	// TODO: Report Stats + Timing
-
+	if _res = _handler.checkFingerprint(_ctx, "Greet"); _res != nil {
+		return
+	}
 	var _req GreetRequest
 	_decoder := json.NewDecoder(_ctx.Request.Body)
 	defer _ctx.Request.Body.Close()
 	_err := _decoder.Decode(&_req)
 	if _err != nil {
-		// TODO: Report Error
-
 		_res = httpserve.NewJSONResponse(400, _err)
 		return
 	}
 	var _resp GreetResponse
-	_resp.Message, _err = _handler.svc.Greet(_req.Name)
+	_err = _handler.invoke(_ctx.Request.Context(), _ctx.Request.Header, "Greet", &_req, &_resp, func(ctx context.Context) (_err error) {
+		if _err = validate.Struct(&_req); _err != nil {
+			return
+		}
+		_resp.Message, _err = _handler.svc.Greet(_req.Name)
+		return
+	})
 	if _err != nil {
-		// TODO: Report Error
-
-		_res = httpserve.NewJSONResponse(400, _err)
+		rpcHandlerWriteError(_ctx.Writer, _err)
+		_res = httpserve.NewAdoptResponse()
 		return
 	}
 
@@ -75,32 +131,44 @@ func (_handler *rpcHandler) Greet(_ctx *httpserve.Context) (_res httpserve.Respo
 	return
 }
 
+// SendPostcardRequest holds params of SendPostcard.
+//
+// SendPostcard sends the postcard to its recipient,
+// the recipient must be set.
 type SendPostcardRequest struct {
-	Card *Postcard `json:"card,omitempty"`
+	Card *Postcard `json:"card,omitempty" validate:"required"`
 }
 
+// SendPostcardResponse holds results of SendPostcard.
+//
+// SendPostcard sends the postcard to its recipient,
+// the recipient must be set.
 type SendPostcardResponse struct {
 }
 
 func (_handler *rpcHandler) SendPostcard(_ctx *httpserve.Context) (_res httpserve.Response) {
-	// TODO: Report Stats + Timing
-
+	if _res = _handler.checkFingerprint(_ctx, "SendPostcard"); _res != nil {
+		return
+	}
 	var _req SendPostcardRequest
 	_decoder := json.NewDecoder(_ctx.Request.Body)
 	defer _ctx.Request.Body.Close()
 	_err := _decoder.Decode(&_req)
 	if _err != nil {
-		// TODO: Report Error
-
 		_res = httpserve.NewJSONResponse(400, _err)
 		return
 	}
 	var _resp SendPostcardResponse
-	_err = _handler.svc.SendPostcard(_req.Card)
+	_err = _handler.invoke(_ctx.Request.Context(), _ctx.Request.Header, "SendPostcard", &_req, &_resp, func(ctx context.Context) (_err error) {
+		if _err = validate.Struct(&_req); _err != nil {
+			return
+		}
+		_err = _handler.svc.SendPostcard(_req.Card)
+		return
+	})
 	if _err != nil {
-		// TODO: Report Error
-
-		_res = httpserve.NewJSONResponse(400, _err)
+		rpcHandlerWriteError(_ctx.Writer, _err)
+		_res = httpserve.NewAdoptResponse()
 		return
 	}
 
@@ -108,6 +176,240 @@ func (_handler *rpcHandler) SendPostcard(_ctx *httpserve.Context) (_res httpserv
 	return
 }
 
+func (_handler *rpcHandler) checkFingerprint(_ctx *httpserve.Context, method string) httpserve.Response {
+	_ctx.Writer.Header().Set("X-MeshRPC-Fingerprint", RPCHandlerFingerprint)
+	if err := _handler.matchFingerprint(_ctx.Request.Header.Get("X-MeshRPC-Fingerprint"), method); err != nil {
+		return httpserve.NewJSONResponse(412, err)
+	}
+	return nil
+}
+
+// invoke starts a span of the call of the service method, continuing the trace of the
+// caller, and passes the call through interceptors of the handler options, then makes it
+// with fn. The context of the call expires along with the deadline of the client, and is
+// cancelled once the client goes away, then the service isn't called at all if it's not yet.
+// Calls with an idempotency key are made once, see callOnce.
+func (_handler *rpcHandler) invoke(ctx context.Context, header http.Header,
+	method string, req, resp interface{}, fn func(ctx context.Context) error) (err error) {
+	ctx, cancel := rpcHandlerWithTimeout(ctx, header)
+	defer cancel()
+	ctx, span := trace.Start(ctx, "greeter.Service/"+method, trace.KindServer, header)
+	defer func() {
+		if err != nil {
+			status, _ := rpcHandlerErrorStatus(err)
+			span.SetStatus(status)
+		}
+		span.Finish(err)
+	}()
+	if len(_handler.opt.Interceptors) == 0 {
+		return _handler.callOnce(ctx, header, method, req, resp, fn)
+	}
+	call := &intercept.Call{
+		Service:  "greeter.Service",
+		Method:   method,
+		Request:  req,
+		Response: resp,
+		Header:   header,
+		Server:   true,
+	}
+	return intercept.Invoke(ctx, _handler.opt.Interceptors, call, func(ctx context.Context, _ *intercept.Call) error {
+		return _handler.callOnce(ctx, header, method, req, resp, fn)
+	})
+}
+
+// rpcHandlerCallAlive calls fn unless ctx is done, e.g. the client has gone away.
+func rpcHandlerCallAlive(ctx context.Context, fn func(ctx context.Context) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return fn(ctx)
+}
+
+const (
+	// rpcHandlerIdempotencyKeys is the default number of idempotency keys a handler keeps.
+	rpcHandlerIdempotencyKeys = 1024
+	// rpcHandlerIdempotencyTTL is the default time a handler keeps an idempotency key for.
+	rpcHandlerIdempotencyTTL = 10 * time.Minute
+)
+
+// callOnce makes the call with fn, unless it carries the idempotency key of a call to the method
+// that has been made already, then the response of that call is replayed into resp. The cache of
+// keys is created along with the first call that carries one. Calls are deduplicated inside
+// interceptors, so that replays are authorized the same way as calls.
+func (_handler *rpcHandler) callOnce(ctx context.Context, header http.Header,
+	method string, req, resp interface{}, fn func(ctx context.Context) error) error {
+	key := header.Get(dedup.KeyHeader)
+	if len(key) == 0 || _handler.opt.IdempotencyKeys < 0 {
+		return rpcHandlerCallAlive(ctx, fn)
+	}
+	_handler.dedupOnce.Do(func() {
+		size, ttl := _handler.opt.IdempotencyKeys, _handler.opt.IdempotencyTTL
+		if size == 0 {
+			size = rpcHandlerIdempotencyKeys
+		}
+		if ttl <= 0 {
+			ttl = rpcHandlerIdempotencyTTL
+		}
+		_handler.dedup = dedup.NewCache(size, ttl)
+	})
+	return _handler.dedup.Do(ctx, method+"/"+key, req, resp, func() error {
+		return rpcHandlerCallAlive(ctx, fn)
+	})
+}
+
+// rpcHandlerWithTimeout derives the deadline of the call from the X-MeshRPC-Timeout
+// header, that is the time left until the deadline of the client, e.g. "1.5s".
+func rpcHandlerWithTimeout(ctx context.Context, header http.Header) (context.Context, context.CancelFunc) {
+	timeout, err := time.ParseDuration(header.Get("X-MeshRPC-Timeout"))
+	if err != nil {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// matchFingerprint returns an error if the fingerprint of the client doesn't match
+// the one of the handler, unless mismatches are permitted.
+func (_handler *rpcHandler) matchFingerprint(fingerprint, method string) error {
+	if len(fingerprint) == 0 || fingerprint == RPCHandlerFingerprint {
+		return nil
+	}
+	if _handler.opt.PermissiveFingerprint {
+		atomic.AddUint64(&_handler.fingerprintMismatches, 1)
+		log.Printf("rpcHandler: %s called by a client with fingerprint %s, expected %s",
+			method, fingerprint, RPCHandlerFingerprint)
+		return nil
+	}
+	return fmt.Errorf("interface fingerprint mismatch: client %s, service %s",
+		fingerprint, RPCHandlerFingerprint)
+}
+
+func (_handler *rpcHandler) FingerprintMismatches() uint64 {
+	return atomic.LoadUint64(&_handler.fingerprintMismatches)
+}
+
+// rpcHandlerMaxBatchCalls is the default limit of calls in a batch request.
+const rpcHandlerMaxBatchCalls = 100
+
+// rpcHandlerBatchCall is a single call of a batch request,
+// params are encoded as the request model of the method.
+type rpcHandlerBatchCall struct {
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params,omitempty"`
+}
+
+// rpcHandlerBatchResult is the result of a single call of a batch request,
+// it's the response envelope of the call along with the status it would be sent with.
+type rpcHandlerBatchResult struct {
+	Status int         `json:"status"`
+	Data   interface{} `json:"data,omitempty"`
+	Errors []string    `json:"errors,omitempty"`
+}
+
+// BatchHandler returns the handler of batch requests, each carries a list of calls,
+// possibly to different methods. Calls are served in order and get a result each,
+// so a failed call doesn't affect the others. See cluster.BatchSpec.
+func (_handler *rpcHandler) BatchHandler() http.Handler {
+	return http.HandlerFunc(_handler.serveBatch)
+}
+
+func (_handler *rpcHandler) serveBatch(_w http.ResponseWriter, _r *http.Request) {
+	_w.Header().Set("X-MeshRPC-Fingerprint", RPCHandlerFingerprint)
+	_err := _handler.matchFingerprint(_r.Header.Get("X-MeshRPC-Fingerprint"), "__batch__")
+	if _err != nil {
+		rpcHandlerWriteJSON(_w, 412, nil, _err)
+		return
+	}
+	var _calls []rpcHandlerBatchCall
+	_decoder := json.NewDecoder(_r.Body)
+	defer _r.Body.Close()
+	if _err = _decoder.Decode(&_calls); _err != nil {
+		rpcHandlerWriteJSON(_w, 400, nil, _err)
+		return
+	}
+	_max := _handler.opt.MaxBatchCalls
+	if _max <= 0 {
+		_max = rpcHandlerMaxBatchCalls
+	}
+	if len(_calls) > _max {
+		_err = fmt.Errorf("too many calls in a batch: %d, max %d", len(_calls), _max)
+		rpcHandlerWriteJSON(_w, 400, nil, _err)
+		return
+	}
+	// calls of the batch share the deadline of the client
+	_ctx, _cancel := rpcHandlerWithTimeout(_r.Context(), _r.Header)
+	defer _cancel()
+	// calls of the batch are told apart by their index within the idempotency key of the batch
+	_key := _r.Header.Get(dedup.KeyHeader)
+	_results := make([]rpcHandlerBatchResult, len(_calls))
+	for _i, _call := range _calls {
+		_header := _r.Header
+		if len(_key) > 0 {
+			_header = make(http.Header, len(_r.Header))
+			for _k, _v := range _r.Header {
+				_header[_k] = _v
+			}
+			_header.Set(dedup.KeyHeader, _key+"/"+strconv.Itoa(_i))
+		}
+		_data, _status, _err := _handler.batchCall(_ctx, _header, _call)
+		_results[_i].Status = _status
+		_results[_i].Data = _data
+		if _err != nil {
+			_results[_i].Errors = []string{_err.Error()}
+		}
+	}
+	rpcHandlerWriteJSON(_w, 200, _results, nil)
+}
+
+// batchCall serves a single call of a batch request, it returns the response model
+// and the status, or the data of the error. Calls are intercepted the same way as single ones.
+func (_handler *rpcHandler) batchCall(_ctx context.Context, _header http.Header,
+	_call rpcHandlerBatchCall) (interface{}, int, error) {
+	switch _call.Method {
+	case "Greet":
+		var _req GreetRequest
+		if len(_call.Params) > 0 {
+			if _err := json.Unmarshal(_call.Params, &_req); _err != nil {
+				return nil, 400, _err
+			}
+		}
+		var _resp GreetResponse
+		_err := _handler.invoke(_ctx, _header, "Greet", &_req, &_resp, func(ctx context.Context) (_err error) {
+			if _err = validate.Struct(&_req); _err != nil {
+				return
+			}
+			_resp.Message, _err = _handler.svc.Greet(_req.Name)
+			return
+		})
+		if _err != nil {
+			_status, _data := rpcHandlerErrorStatus(_err)
+			return _data, _status, _err
+		}
+		return &_resp, 200, nil
+	case "SendPostcard":
+		var _req SendPostcardRequest
+		if len(_call.Params) > 0 {
+			if _err := json.Unmarshal(_call.Params, &_req); _err != nil {
+				return nil, 400, _err
+			}
+		}
+		var _resp SendPostcardResponse
+		_err := _handler.invoke(_ctx, _header, "SendPostcard", &_req, &_resp, func(ctx context.Context) (_err error) {
+			if _err = validate.Struct(&_req); _err != nil {
+				return
+			}
+			_err = _handler.svc.SendPostcard(_req.Card)
+			return
+		})
+		if _err != nil {
+			_status, _data := rpcHandlerErrorStatus(_err)
+			return _data, _status, _err
+		}
+		return &_resp, 200, nil
+	default:
+		return nil, 404, fmt.Errorf("method %s not found", _call.Method)
+	}
+}
+
 var rpcHandlerMethodsMap = map[string][]string{
 	"*": []string{
 		"POST",
@@ -118,6 +420,53 @@ func (_ *rpcHandler) HTTPMethodsMap() map[string][]string {
 	return rpcHandlerMethodsMap
 }
 
-type ErrorResponse struct {
-	Error string `json:"error"`
+var rpcHandlerMethodDocs = map[string]string{
+	"Greet":        "Greet returns a greeting message for the person.",
+	"SendPostcard": "SendPostcard sends the postcard to its recipient,\nthe recipient must be set.",
+}
+
+// MethodDocs returns doc comments of the service interface methods, by method name.
+func (_ *rpcHandler) MethodDocs() map[string]string {
+	return rpcHandlerMethodDocs
+}
+
+// rpcHandlerJSONValue is the JSON response envelope, the same as used by httpserve.
+type rpcHandlerJSONValue struct {
+	Data   interface{} `json:"data,omitempty"`
+	Errors []string    `json:"errors,omitempty"`
+}
+
+func rpcHandlerWriteJSON(w http.ResponseWriter, status int, data interface{}, err error) {
+	value := &rpcHandlerJSONValue{
+		Data: data,
+	}
+	if err != nil {
+		value.Errors = []string{err.Error()}
+	}
+	body, err := json.Marshal(value)
+	if err != nil {
+		log.Printf("rpcHandler: failed to encode response: %v", err)
+		status = http.StatusInternalServerError
+		body, _ = json.Marshal(&rpcHandlerJSONValue{
+			Errors: []string{err.Error()},
+		})
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(body)
+}
+
+// rpcHandlerWriteError writes the error of a call, see rpcHandlerErrorStatus.
+func rpcHandlerWriteError(w http.ResponseWriter, err error) {
+	status, data := rpcHandlerErrorStatus(err)
+	rpcHandlerWriteJSON(w, status, data, err)
+}
+
+// rpcHandlerErrorStatus returns the status to respond to the error of a call with,
+// see intercept.Status, along with the data. Field errors of validation are the data.
+func rpcHandlerErrorStatus(err error) (int, interface{}) {
+	if fieldErrs, ok := err.(validate.Errors); ok {
+		return http.StatusBadRequest, fieldErrs
+	}
+	return intercept.Status(err), nil
 }
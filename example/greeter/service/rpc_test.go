@@ -0,0 +1,544 @@
+package greeter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/astranet/meshRPC/intercept"
+	"github.com/astranet/meshRPC/retry"
+	"github.com/astranet/meshRPC/trace"
+	"github.com/astranet/meshRPC/validate"
+	"github.com/pkg/errors"
+)
+
+// testHTTPClient serves requests of the client with a func, in place of the cluster.
+type testHTTPClient func(req *http.Request) *http.Response
+
+func (fn testHTTPClient) Do(req *http.Request) (*http.Response, error) {
+	return fn(req), nil
+}
+
+func testResponse(status int, header http.Header, body string) *http.Response {
+	if header == nil {
+		header = make(http.Header)
+	}
+	return &http.Response{
+		StatusCode: status,
+		Status:     http.StatusText(status),
+		Header:     header,
+		Body:       ioutil.NopCloser(strings.NewReader(body)),
+	}
+}
+
+func postBatch(h RPCHandler, header http.Header, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest("POST", "/rpcHandler/__batch__", bytes.NewBufferString(body))
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	rec := httptest.NewRecorder()
+	h.BatchHandler().ServeHTTP(rec, req)
+	return rec
+}
+
+// serveBatches serves batch requests of the client with the handler.
+func serveBatches(t *testing.T, h RPCHandler) testHTTPClient {
+	return func(req *http.Request) *http.Response {
+		if req.URL.Path != "__batch__" {
+			t.Errorf("request path = %q, want __batch__", req.URL.Path)
+		}
+		rec := httptest.NewRecorder()
+		h.BatchHandler().ServeHTTP(rec, req)
+		return rec.Result()
+	}
+}
+
+func TestFingerprintMismatch(t *testing.T) {
+	client := NewServiceClient(testHTTPClient(func(req *http.Request) *http.Response {
+		if fp := req.Header.Get("X-MeshRPC-Fingerprint"); fp != ServiceClientFingerprint {
+			t.Errorf("client fingerprint = %q, want %q", fp, ServiceClientFingerprint)
+		}
+		header := make(http.Header)
+		header.Set("X-MeshRPC-Fingerprint", "0123456789abcdef")
+		return testResponse(http.StatusPreconditionFailed, header, "")
+	}), nil)
+	_, err := client.Greet("John")
+	if errors.Cause(err) != ErrFingerprintMismatch {
+		t.Errorf("Greet() error = %v, want %v", err, ErrFingerprintMismatch)
+	}
+
+	stale := http.Header{"X-Meshrpc-Fingerprint": {"0123456789abcdef"}}
+	h := NewRPCHandler(NewService(), nil)
+	rec := postBatch(h, stale, `[{"method":"Greet","params":{"name":"John"}}]`)
+	if rec.Code != http.StatusPreconditionFailed {
+		t.Errorf("status of a stale client = %d, want 412", rec.Code)
+	}
+	if fp := rec.Header().Get("X-MeshRPC-Fingerprint"); fp != RPCHandlerFingerprint {
+		t.Errorf("handler fingerprint = %q, want %q", fp, RPCHandlerFingerprint)
+	}
+
+	h = NewRPCHandler(NewService(), &RPCHandlerOptions{
+		PermissiveFingerprint: true,
+	})
+	rec = postBatch(h, stale, `[{"method":"Greet","params":{"name":"John"}}]`)
+	if rec.Code != http.StatusOK || h.FingerprintMismatches() != 1 {
+		t.Errorf("permissive handler: status %d, %d mismatches, want 200 and 1", rec.Code, h.FingerprintMismatches())
+	}
+}
+
+func TestMethodDocs(t *testing.T) {
+	docs := NewRPCHandler(NewService(), nil).(interface {
+		MethodDocs() map[string]string
+	}).MethodDocs()
+	want := "SendPostcard sends the postcard to its recipient,\nthe recipient must be set."
+	if len(docs) != 2 || docs["SendPostcard"] != want {
+		t.Errorf("MethodDocs() = %q, want docs of both methods", docs)
+	}
+}
+
+func TestCallOptions(t *testing.T) {
+	client := NewServiceClient(testHTTPClient(func(req *http.Request) *http.Response {
+		if req.URL.Path != "Greet" {
+			t.Errorf("request path = %q, want Greet", req.URL.Path)
+		}
+		timeout, err := time.ParseDuration(req.Header.Get("X-MeshRPC-Timeout"))
+		if err != nil || timeout <= 0 || timeout > time.Minute {
+			t.Errorf("X-MeshRPC-Timeout = %q, want up to a minute", req.Header.Get("X-MeshRPC-Timeout"))
+		}
+		if v := req.Header["X-Tenant"]; !reflect.DeepEqual(v, []string{"a", "b"}) {
+			t.Errorf("X-Tenant = %v, want [a b]", v)
+		}
+		if v := req.Header.Get("X-MeshRPC-Target"); v != "instance-1" {
+			t.Errorf("X-MeshRPC-Target = %q, want instance-1", v)
+		}
+		return testResponse(http.StatusOK, nil, `{"data":{"message":"Hello, John"}}`)
+	}), nil)
+	message, err := client.GreetContext(context.Background(), "John",
+		CallTimeout(time.Minute),
+		CallHeader("X-Tenant", "a"),
+		CallHeader("X-Tenant", "b"),
+		CallTarget("instance-1"),
+	)
+	if err != nil || message != "Hello, John" {
+		t.Errorf("GreetContext() = %q, %v, want a greeting", message, err)
+	}
+}
+
+func TestCallTimeout(t *testing.T) {
+	ctx, cancel := rpcHandlerWithTimeout(context.Background(), http.Header{
+		"X-Meshrpc-Timeout": {"1.5s"},
+	})
+	defer cancel()
+	deadline, ok := ctx.Deadline()
+	if left := time.Until(deadline); !ok || left <= time.Second || left > 1500*time.Millisecond {
+		t.Errorf("deadline in %v, want in 1.5s", left)
+	}
+
+	ctx, cancel = rpcHandlerWithTimeout(context.Background(), http.Header{
+		"X-Meshrpc-Timeout": {"soon"},
+	})
+	defer cancel()
+	if _, ok := ctx.Deadline(); ok {
+		t.Error("invalid timeout sets a deadline")
+	}
+}
+
+func TestFutures(t *testing.T) {
+	release := make(chan struct{})
+	client := NewServiceClient(testHTTPClient(func(req *http.Request) *http.Response {
+		<-release
+		var greetReq GreetRequest
+		if err := json.NewDecoder(req.Body).Decode(&greetReq); err != nil {
+			t.Error(err)
+		}
+		if greetReq.Name == "nobody" {
+			return testResponse(http.StatusBadRequest, nil, `{"errors":["no name"]}`)
+		}
+		return testResponse(http.StatusOK, nil, `{"data":{"message":"Hello, `+greetReq.Name+`"}}`)
+	}), nil)
+	ctx := context.Background()
+	john := client.GreetAsync(ctx, "John")
+	nobody := client.GreetAsync(ctx, "nobody")
+
+	waitCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	if err := WaitAll(waitCtx, john, nobody); err != context.DeadlineExceeded {
+		t.Errorf("WaitAll() of pending calls = %v, want %v", err, context.DeadlineExceeded)
+	}
+
+	close(release)
+	err := WaitAll(ctx, john, nobody)
+	if statusErr, ok := err.(*intercept.StatusError); !ok || statusErr.Status != http.StatusBadRequest {
+		t.Errorf("WaitAll() = %v, want the error of the failed call", err)
+	}
+	if message, err := john.Wait(); err != nil || message != "Hello, John" {
+		t.Errorf("Wait() = %q, %v, want a greeting", message, err)
+	}
+	if err := nobody.Err(); err == nil {
+		t.Error("Err() of the failed call is nil")
+	}
+}
+
+func TestBatch(t *testing.T) {
+	h := NewRPCHandler(NewService(), &RPCHandlerOptions{
+		MaxBatchCalls: 3,
+	})
+	client := NewServiceClient(serveBatches(t, h), nil)
+	batch := client.Batch()
+	john := batch.Greet("John")
+	nobody := batch.Greet("")
+	card := batch.SendPostcard(&Postcard{Recipient: "John"})
+	if err := batch.Send(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if message, err := john.Wait(); err != nil || message != "Hello, John" {
+		t.Errorf("Greet(John) = %q, %v, want a greeting", message, err)
+	}
+	if statusErr, ok := nobody.Err().(*intercept.StatusError); !ok || statusErr.Status != http.StatusBadRequest {
+		t.Errorf("Greet() error = %v, want a validation error", nobody.Err())
+	}
+	if err := card.Err(); err != nil {
+		t.Errorf("SendPostcard() error = %v", err)
+	}
+	if err := batch.Send(context.Background()); err != nil {
+		t.Errorf("Send() of an empty batch = %v", err)
+	}
+
+	for i := 0; i < 4; i++ {
+		batch.Greet("John")
+	}
+	last := batch.Greet("John")
+	err := batch.Send(context.Background())
+	if statusErr, ok := err.(*intercept.StatusError); !ok || statusErr.Status != http.StatusBadRequest {
+		t.Errorf("Send() of too many calls = %v, want 400", err)
+	}
+	if last.Err() != err {
+		t.Errorf("future error = %v, want the error of the batch %v", last.Err(), err)
+	}
+
+	rec := postBatch(h, nil, `[{"method":"Missing"},{"method":"Greet","params":{"name":"John"}}]`)
+	var results []struct {
+		Status int `json:"status"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &struct {
+		Data interface{} `json:"data"`
+	}{&results}); err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 2 || results[0].Status != http.StatusNotFound || results[1].Status != http.StatusOK {
+		t.Errorf("batch results = %+v, want 404 and 200", results)
+	}
+}
+
+func TestBatchIdempotency(t *testing.T) {
+	var keys []string
+	h := NewRPCHandler(NewService(), nil)
+	serve := serveBatches(t, h)
+	client := NewServiceClient(testHTTPClient(func(req *http.Request) *http.Response {
+		keys = append(keys, req.Header.Get("Idempotency-Key"))
+		return serve(req)
+	}), nil)
+	batch := client.Batch()
+	batch.Greet("John")
+	batch.Greet("Jane")
+	if err := batch.Send(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	batch.Greet("John")
+	batch.SendPostcard(&Postcard{Recipient: "John"})
+	if err := batch.Send(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if len(keys) != 2 || len(keys[0]) == 0 || len(keys[1]) > 0 {
+		t.Errorf("idempotency keys = %q, want one for the batch of idempotent calls only", keys)
+	}
+}
+
+func TestValidation(t *testing.T) {
+	h := NewRPCHandler(NewService(), nil)
+	rec := postBatch(h, nil, `[
+		{"method":"SendPostcard"},
+		{"method":"SendPostcard","params":{"card":{"Recipient":""}}},
+		{"method":"SendPostcard","params":{"card":{"Recipient":"John"}}}
+	]`)
+	var results []struct {
+		Status int             `json:"status"`
+		Data   json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &struct {
+		Data interface{} `json:"data"`
+	}{&results}); err != nil {
+		t.Fatal(err)
+	}
+	want := []validate.Errors{
+		{{Field: "card", Rule: "required", Message: "is required"}},
+		{{Field: "card.Recipient", Rule: "required", Message: "is required"}},
+		nil,
+	}
+	if len(results) != len(want) {
+		t.Fatalf("got %d results, want %d", len(results), len(want))
+	}
+	for i, res := range results {
+		if want[i] == nil {
+			if res.Status != http.StatusOK {
+				t.Errorf("result %d status = %d, want 200", i, res.Status)
+			}
+			continue
+		}
+		var fieldErrs validate.Errors
+		if err := json.Unmarshal(res.Data, &fieldErrs); err != nil {
+			t.Fatal(err)
+		}
+		if res.Status != http.StatusBadRequest || !reflect.DeepEqual(fieldErrs, want[i]) {
+			t.Errorf("result %d = %d %v, want 400 %v", i, res.Status, fieldErrs, want[i])
+		}
+	}
+}
+
+func TestInterceptors(t *testing.T) {
+	var calls []string
+	h := NewRPCHandler(NewService(), &RPCHandlerOptions{
+		Interceptors: []intercept.Interceptor{
+			func(ctx context.Context, call *intercept.Call, next intercept.Invoker) error {
+				calls = append(calls, call.Service+"/"+call.Method)
+				if !call.Server || call.Header.Get("X-User") != "admin" {
+					return &intercept.StatusError{
+						Status: http.StatusForbidden,
+						Err:    errors.New("forbidden"),
+					}
+				}
+				if req := call.Request.(*GreetRequest); req.Name != "John" {
+					t.Errorf("intercepted request %+v, want a decoded one", req)
+				}
+				err := next(ctx, call)
+				if resp := call.Response.(*GreetResponse); resp.Message != "Hello, John" {
+					t.Errorf("intercepted response %+v, want the one of the service", resp)
+				}
+				return err
+			},
+		},
+	})
+	newClient := func(user string) ServiceClient {
+		return NewServiceClient(serveBatches(t, h), &ServiceClientOptions{
+			Interceptors: []intercept.Interceptor{
+				func(ctx context.Context, call *intercept.Call, next intercept.Invoker) error {
+					if call.Server || call.Method != "__batch__" {
+						t.Errorf("client intercepted %s, server %v", call.Method, call.Server)
+					}
+					call.Header.Set("X-User", user)
+					return next(ctx, call)
+				},
+			},
+		})
+	}
+	for user, wantErr := range map[string]bool{"admin": false, "guest": true} {
+		batch := newClient(user).Batch()
+		f := batch.Greet("John")
+		if err := batch.Send(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+		message, err := f.Wait()
+		if wantErr {
+			if statusErr, ok := err.(*intercept.StatusError); !ok || statusErr.Status != http.StatusForbidden {
+				t.Errorf("%s: Greet() = %q, %v, want 403", user, message, err)
+			}
+		} else if err != nil || message != "Hello, John" {
+			t.Errorf("%s: Greet() = %q, %v, want a greeting", user, message, err)
+		}
+	}
+	if want := []string{"greeter.Service/Greet", "greeter.Service/Greet"}; !reflect.DeepEqual(calls, want) {
+		t.Errorf("intercepted %q, want %q", calls, want)
+	}
+}
+
+// testExporter keeps exported spans.
+type testExporter []*trace.Span
+
+func (e *testExporter) ExportSpan(span *trace.Span) error {
+	*e = append(*e, span)
+	return nil
+}
+
+func TestTracePropagation(t *testing.T) {
+	var spans testExporter
+	trace.SetExporter(&spans)
+	defer trace.SetExporter(nil)
+
+	h := NewRPCHandler(NewService(), nil)
+	client := NewServiceClient(serveBatches(t, h), nil)
+	ctx, root := trace.Start(context.Background(), "root", trace.KindClient, nil)
+	batch := client.Batch()
+	batch.Greet("John")
+	if err := batch.Send(ctx); err != nil {
+		t.Fatal(err)
+	}
+	root.Finish(nil)
+
+	// the server span of the call, the client span of the batch and the root span
+	if len(spans) != 3 {
+		t.Fatalf("exported %d spans, want 3", len(spans))
+	}
+	server, call := spans[0], spans[1]
+	if server.Kind != trace.KindServer || server.Name != "greeter.Service/Greet" || server.TraceID != root.TraceID {
+		t.Errorf("server span %+v, want one of Greet in the trace of the root", server)
+	}
+	if call.Kind != trace.KindClient || *call.ParentID != root.SpanID || *server.ParentID != call.SpanID {
+		t.Errorf("client span %+v, want a child of the root and the parent of the server span", call)
+	}
+}
+
+func TestDeadlinePropagation(t *testing.T) {
+	h := NewRPCHandler(NewService(), nil)
+	serve := serveBatches(t, h)
+	client := NewServiceClient(testHTTPClient(func(req *http.Request) *http.Response {
+		if _, err := time.ParseDuration(req.Header.Get("X-MeshRPC-Timeout")); err != nil {
+			t.Errorf("X-MeshRPC-Timeout = %q, want the time left", req.Header.Get("X-MeshRPC-Timeout"))
+		}
+		return serve(req)
+	}), nil)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	batch := client.Batch()
+	f := batch.Greet("John")
+	if err := batch.Send(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Err(); err != nil {
+		t.Errorf("Greet() error = %v", err)
+	}
+
+	// the deadline of the client has passed by the time the handler gets the call
+	rec := postBatch(h, http.Header{"X-Meshrpc-Timeout": {"1ns"}}, `[{"method":"Greet","params":{"name":"John"}}]`)
+	if !strings.Contains(rec.Body.String(), `"status":504`) {
+		t.Errorf("batch response %s, want 504 for the call", rec.Body.String())
+	}
+}
+
+func TestCancelledCall(t *testing.T) {
+	h := NewRPCHandler(NewService(), nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	req := httptest.NewRequest("POST", "/rpcHandler/__batch__",
+		bytes.NewBufferString(`[{"method":"Greet","params":{"name":"John"}}]`)).WithContext(ctx)
+	rec := httptest.NewRecorder()
+	h.BatchHandler().ServeHTTP(rec, req)
+	if !strings.Contains(rec.Body.String(), `"status":499`) {
+		t.Errorf("batch response %s, want 499 for the call", rec.Body.String())
+	}
+}
+
+func TestRetries(t *testing.T) {
+	var (
+		attempts int
+		keys     []string
+	)
+	client := NewServiceClient(testHTTPClient(func(req *http.Request) *http.Response {
+		attempts++
+		keys = append(keys, req.Header.Get("Idempotency-Key"))
+		return testResponse(http.StatusServiceUnavailable, nil, `{"error":"unavailable"}`)
+	}), &ServiceClientOptions{
+		RetryPolicy: &retry.Policy{MaxAttempts: 3, MinBackoff: time.Millisecond},
+	})
+	tests := []struct {
+		name string
+		call func() error
+		want int
+	}{{
+		name: "idempotent method",
+		call: func() error {
+			_, err := client.Greet("John")
+			return err
+		},
+		want: 3,
+	}, {
+		name: "other method",
+		call: func() error {
+			return client.SendPostcard(&Postcard{Recipient: "John"})
+		},
+		want: 1,
+	}, {
+		name: "other method with CallRetries",
+		call: func() error {
+			return client.SendPostcardContext(context.Background(), &Postcard{Recipient: "John"}, CallRetries(1))
+		},
+		want: 2,
+	}, {
+		name: "idempotent method with CallRetries(0)",
+		call: func() error {
+			_, err := client.GreetContext(context.Background(), "John", CallRetries(0))
+			return err
+		},
+		want: 1,
+	}}
+	for _, tt := range tests {
+		attempts, keys = 0, nil
+		if err := tt.call(); err == nil {
+			t.Errorf("%s: got no error of the 503 response", tt.name)
+		}
+		if attempts != tt.want {
+			t.Errorf("%s: %d attempts, want %d", tt.name, attempts, tt.want)
+		}
+		for _, key := range keys {
+			if tt.want > 1 && (len(key) == 0 || key != keys[0]) {
+				t.Errorf("%s: idempotency keys %q, want the same one with every attempt", tt.name, keys)
+				break
+			}
+		}
+	}
+}
+
+// countingService counts calls of SendPostcard.
+type countingService struct {
+	Service
+	postcards int
+}
+
+func (s *countingService) SendPostcard(card *Postcard) error {
+	s.postcards++
+	return nil
+}
+
+func TestIdempotencyKeys(t *testing.T) {
+	withKey := http.Header{"Idempotency-Key": {"key-1"}}
+	johnCard := `[{"method":"SendPostcard","params":{"card":{"Recipient":"John"}}}]`
+	janeCard := `[{"method":"SendPostcard","params":{"card":{"Recipient":"Jane"}}}]`
+
+	svc := &countingService{Service: NewService()}
+	h := NewRPCHandler(svc, nil)
+	for i := 0; i < 2; i++ {
+		if rec := postBatch(h, withKey, johnCard); !strings.Contains(rec.Body.String(), `"status":200`) {
+			t.Errorf("batch response %s, want 200 for the call", rec.Body.String())
+		}
+	}
+	if svc.postcards != 1 {
+		t.Errorf("%d postcards sent, want a retry with the same key replayed", svc.postcards)
+	}
+	if rec := postBatch(h, withKey, janeCard); !strings.Contains(rec.Body.String(), `"status":422`) {
+		t.Errorf("batch response %s, want 422 for a key used with another request", rec.Body.String())
+	}
+
+	svc = &countingService{Service: NewService()}
+	h = NewRPCHandler(svc, &RPCHandlerOptions{IdempotencyKeys: -1})
+	postBatch(h, withKey, johnCard)
+	postBatch(h, withKey, johnCard)
+	if svc.postcards != 2 {
+		t.Errorf("%d postcards sent with keys turned off, want 2", svc.postcards)
+	}
+}
+
+func TestSendPostcardInProcess(t *testing.T) {
+	svc := NewService()
+	if err := svc.SendPostcard(nil); err == nil {
+		t.Error("SendPostcard(nil) succeeded")
+	}
+	if err := svc.SendPostcard(&Postcard{}); err == nil {
+		t.Error("SendPostcard() without a recipient succeeded")
+	}
+}
@@ -8,15 +8,25 @@ import (
 type Postcard struct {
 	PictureURL string
 	Address    string
-	Recipient  string
-	Message    string
+	Recipient  string `validate:"required,max=64"`
+	Message    string `validate:"max=1024"`
 }
 
 //go:generate meshRPC expose -P greeter -y
 
 type Service interface {
-	Greet(name string) (message string, err error)
-	SendPostcard(card *Postcard) (err error)
+	// Greet returns a greeting message for the person.
+	//meshrpc:idempotent
+	Greet(
+		// name of the person to greet
+		// validate:"required,max=64"
+		name string,
+	) (message string, err error)
+	// SendPostcard sends the postcard to its recipient,
+	// the recipient must be set.
+	SendPostcard(
+		card *Postcard, // validate:"required"
+	) (err error)
 }
 
 func NewService() Service {
@@ -31,6 +41,7 @@ func (s *service) Greet(name string) (string, error) {
 }
 
 func (s *service) SendPostcard(card *Postcard) error {
+	// handlers validate requests, but in-process calls skip them
 	if card == nil {
 		return errors.New("no postcard")
 	} else if card.Recipient == "" {
@@ -77,14 +77,14 @@ func httpListenAndServe(c cluster.Cluster) {
 	greeterClient := c.NewClient("greeter", greeter.RPCHandlerSpec)
 	// A greeter.ServiceClient instance successfully conforms the greeter.Service interface
 	// and may be used in place of the local greeter.Service instance.
-	var svc greeter.Service = greeter.NewServiceClient(greeterClient, nil)
+	svc := greeter.NewServiceClient(greeterClient, nil)
 
 	// Set an endpoint handler for the Greet function.
 	// Example Request:
 	// $ curl http://localhost:8282/greeter/greet/Max
 	router.GET("/greeter/greet/:name", func(c *httpserve.Context) httpserve.Response {
-		// Service call is actually done over meshRPC...
-		message, err := svc.Greet(c.Param("name"))
+		// Service call is actually done over meshRPC, and is aborted if the caller goes away...
+		message, err := svc.GreetContext(c.Request.Context(), c.Param("name"))
 		if err != nil {
 			return httpserve.NewJSONResponse(http.StatusInternalServerError, err)
 		}
@@ -101,8 +101,10 @@ func httpListenAndServe(c cluster.Cluster) {
 			Address:   c.Param("address"),
 			Message:   c.Param("message"),
 		}
-		// Service call is actually done over meshRPC...
-		err := svc.SendPostcard(postcard)
+		// Service call is actually done over meshRPC, and is aborted if the caller goes away.
+		// Sending a postcard is not safe to repeat, but retries carry an idempotency key,
+		// so the service doesn't send it twice if only the response has been lost...
+		err := svc.SendPostcardContext(c.Request.Context(), postcard, greeter.CallRetries(2))
 		if err != nil {
 			return httpserve.NewJSONResponse(http.StatusInternalServerError, err)
 		}
@@ -0,0 +1,376 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/imports"
+)
+
+// Original source for many of these functions:
+// https://raw.githubusercontent.com/josharian/impl/master/impl.go
+
+// findInterface returns the import path and identifier of an interface.
+// For example, given "http.ResponseWriter", findInterface returns
+// "net/http", "ResponseWriter".
+// If a fully qualified interface is given, such as "net/http.ResponseWriter",
+// it simply parses the input.
+func findInterface(iface string, srcDir string) (path string, id string, err error) {
+	if len(strings.Fields(iface)) != 1 {
+		return "", "", fmt.Errorf("couldn't parse interface: %s", iface)
+	}
+
+	srcPath := filepath.Join(srcDir, "..", "__ast_tmp__.go")
+
+	if slash := strings.LastIndex(iface, "/"); slash > -1 {
+		// package path provided
+		dot := strings.LastIndex(iface, ".")
+		// make sure iface does not end with "/" (e.g. reject net/http/)
+		if slash+1 == len(iface) {
+			return "", "", fmt.Errorf("interface name cannot end with a '/' character: %s", iface)
+		}
+		// make sure iface does not end with "." (e.g. reject net/http.)
+		if dot+1 == len(iface) {
+			return "", "", fmt.Errorf("interface name cannot end with a '.' character: %s", iface)
+		}
+		// make sure iface has exactly one "." after "/" (e.g. reject net/http/httputil)
+		if strings.Count(iface[slash:], ".") != 1 {
+			return "", "", fmt.Errorf("invalid interface name: %s", iface)
+		}
+		return iface[:dot], iface[dot+1:], nil
+	}
+
+	src := []byte("package hack\n" + "var i " + iface)
+	// If we couldn't determine the import path, goimports will
+	// auto fix the import path.
+	imp, err := imports.Process(srcPath, src, nil)
+	if err != nil {
+		return "", "", fmt.Errorf("couldn't parse interface: %s", iface)
+	}
+
+	// imp should now contain an appropriate import.
+	// Parse out the import and the identifier.
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, srcPath, imp, 0)
+	if err != nil {
+		panic(err)
+	}
+	if len(f.Imports) == 0 {
+		return "", "", fmt.Errorf("unrecognized interface: %s", iface)
+	}
+	raw := f.Imports[0].Path.Value   // "io"
+	path, err = strconv.Unquote(raw) // io
+	if err != nil {
+		panic(err)
+	}
+	decl := f.Decls[1].(*ast.GenDecl)      // var i io.Reader
+	spec := decl.Specs[0].(*ast.ValueSpec) // i io.Reader
+	sel := spec.Type.(*ast.SelectorExpr)   // io.Reader
+	id = sel.Sel.Name                      // Reader
+	return path, id, nil
+}
+
+// Pkg is a parsed build.Package.
+type Pkg struct {
+	*build.Package
+	*token.FileSet
+}
+
+// buildImport imports the package resolving modules relative to srcDir,
+// instead of the current working dir.
+func buildImport(path string, srcDir string) (*build.Package, error) {
+	ctx := build.Default
+	ctx.Dir = srcDir
+	return ctx.Import(path, srcDir, 0)
+}
+
+// typeSpec locates the *ast.TypeSpec for type id in the import path,
+// along with the file it has been declared in.
+func typeSpec(path string, id string, srcDir string) (Pkg, *ast.File, *ast.TypeSpec, error) {
+	pkg, err := buildImport(path, srcDir)
+	if err != nil {
+		return Pkg{}, nil, nil, fmt.Errorf("couldn't find package %s: %v", path, err)
+	}
+
+	fset := token.NewFileSet() // share one fset across the whole package
+	for _, file := range pkg.GoFiles {
+		f, err := parser.ParseFile(fset, filepath.Join(pkg.Dir, file), nil, parser.ParseComments)
+		if err != nil {
+			continue
+		}
+
+		for _, decl := range f.Decls {
+			decl, ok := decl.(*ast.GenDecl)
+			if !ok || decl.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range decl.Specs {
+				spec := spec.(*ast.TypeSpec)
+				if spec.Name.Name != id {
+					continue
+				}
+				return Pkg{Package: pkg, FileSet: fset}, f, spec, nil
+			}
+		}
+	}
+	return Pkg{}, nil, nil, fmt.Errorf("type %s not found in %s", id, path)
+}
+
+// fullType returns the fully qualified type of e.
+// Examples, assuming package net/http:
+// 	fullType(int) => "int"
+// 	fullType(Handler) => "http.Handler"
+// 	fullType(io.Reader) => "io.Reader"
+// 	fullType(*Request) => "*http.Request"
+func (p Pkg) fullType(e ast.Expr) string {
+	ast.Inspect(e, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.Ident:
+			// Using typeSpec instead of IsExported here would be
+			// more accurate, but it'd be crazy expensive, and if
+			// the type isn't exported, there's no point trying
+			// to implement it anyway.
+			if n.IsExported() {
+				n.Name = p.Package.Name + "." + n.Name
+			}
+		case *ast.SelectorExpr:
+			return false
+		}
+		return true
+	})
+	return p.fmt(e)
+}
+
+func (p Pkg) fmt(e ast.Expr) string {
+	var buf bytes.Buffer
+	printer.Fprint(&buf, p.FileSet, e)
+	return buf.String()
+}
+
+func (p Pkg) params(field *ast.Field, doc string) []Param {
+	var params []Param
+	typ := p.fmt(field.Type)
+	for _, name := range field.Names {
+		params = append(params, Param{Name: name.Name, Type: typ, Doc: doc, expr: field.Type})
+	}
+	// Handle anonymous params
+	if len(params) == 0 {
+		params = []Param{{Type: typ, Doc: doc, expr: field.Type}}
+	}
+	return params
+}
+
+type Method struct {
+	Name string
+	// Doc is the doc comment of the method, without comment markers.
+	Doc    string
+	Params []Param
+	Res    []Param
+	// Oneway is set by the //meshrpc:oneway directive, calls of such methods are
+	// acknowledged right away and served in the background.
+	Oneway bool
+	// Idempotent is set by the //meshrpc:idempotent directive, calls of such methods
+	// are safe to repeat, so clients retry them by their retry policy.
+	Idempotent bool
+	// Context is set if the first param of the method is a context.Context, it's not
+	// in Params, since handlers pass the context of the call there.
+	Context bool
+
+	// ctxName is the name of the context param in the service interface.
+	ctxName string
+	src     *methodSource
+}
+
+type Param struct {
+	Name string
+	Type string
+	// Doc is the comment of the param, either above it or on the same line.
+	Doc string
+	// Validate holds validation rules of the param, see validateRules.
+	Validate string
+
+	expr ast.Expr
+	// index is the position of a param or a result, it names unnamed ones.
+	index  int
+	result bool
+}
+
+// methodSource keeps the AST scope a method has been declared in,
+// so types of its params can be resolved later.
+type methodSource struct {
+	Pkg   Pkg
+	File  *ast.File
+	Field *ast.Field
+}
+
+func (p Pkg) funcsig(file *ast.File, f *ast.Field) Method {
+	fn := Method{
+		Name:       f.Names[0].Name,
+		Doc:        docText(f),
+		Oneway:     hasDirective(f, "oneway"),
+		Idempotent: hasDirective(f, "idempotent"),
+		src: &methodSource{
+			Pkg:   p,
+			File:  file,
+			Field: f,
+		},
+	}
+	typ := f.Type.(*ast.FuncType)
+	if typ.Params != nil {
+		for i, field := range typ.Params.List {
+			doc, rules := validateRules(p.paramDoc(file, typ.Params, i))
+			params := p.params(field, doc)
+			for j := range params {
+				params[j].Validate = rules
+			}
+			fn.Params = append(fn.Params, params...)
+		}
+	}
+	if len(fn.Params) > 0 && fn.Params[0].Type == "context.Context" {
+		fn.Context = true
+		fn.ctxName = fn.Params[0].Name
+		fn.Params = fn.Params[1:]
+	}
+	for i := range fn.Params {
+		fn.Params[i].index = i
+	}
+	if typ.Results != nil {
+		for i, field := range typ.Results.List {
+			fn.Res = append(fn.Res, p.params(field, p.paramDoc(file, typ.Results, i))...)
+		}
+		for i := range fn.Res {
+			fn.Res[i].index = i
+			fn.Res[i].result = true
+		}
+	}
+	return fn
+}
+
+// paramDoc returns the comment of i-th field of a param list, since the parser doesn't
+// attach comments to func params. It's either a comment right above the field,
+// or a line comment that follows the field on the same line.
+func (p Pkg) paramDoc(file *ast.File, list *ast.FieldList, i int) string {
+	if file == nil || !list.Opening.IsValid() {
+		// a single unnamed result can't be commented apart from the method
+		return ""
+	}
+	field := list.List[i]
+	prev, next := list.Opening, list.Closing
+	if i > 0 {
+		prev = list.List[i-1].End()
+	}
+	if i < len(list.List)-1 {
+		next = list.List[i+1].Pos()
+	}
+	prevLine := p.Position(prev).Line
+	line := p.Position(field.Pos()).Line
+	endLine := p.Position(field.End()).Line
+	for _, cg := range file.Comments {
+		if cg.Pos() < prev || cg.End() > next {
+			continue
+		}
+		if cg.End() < field.Pos() && p.Position(cg.Pos()).Line > prevLine &&
+			p.Position(cg.End()).Line == line-1 {
+			return strings.TrimSpace(cg.Text())
+		}
+		if cg.Pos() > field.End() && p.Position(cg.Pos()).Line == endLine {
+			return strings.TrimSpace(cg.Text())
+		}
+	}
+	return ""
+}
+
+// validateRules cuts a `validate:"..."` line out of the param comment and returns the
+// rules. Params can't have struct tags, so the rules go to the request model field.
+func validateRules(doc string) (string, string) {
+	lines := strings.Split(doc, "\n")
+	for i, line := range lines {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, `validate:"`) {
+			continue
+		}
+		rules, ok := reflect.StructTag(line).Lookup("validate")
+		if !ok {
+			continue
+		}
+		lines = append(lines[:i], lines[i+1:]...)
+		return strings.TrimSpace(strings.Join(lines, "\n")), rules
+	}
+	return doc, ""
+}
+
+// docText returns the doc comment of the field, or its line comment if there is no doc.
+// Directives like //go:generate are not a part of the text.
+func docText(f *ast.Field) string {
+	if f.Doc != nil {
+		return strings.TrimSpace(f.Doc.Text())
+	}
+	return strings.TrimSpace(f.Comment.Text())
+}
+
+// directivePrefix starts comments that change how a method is exposed, e.g. //meshrpc:oneway.
+const directivePrefix = "//meshrpc:"
+
+// hasDirective reports whether the doc comment of the field has the named directive.
+func hasDirective(f *ast.Field, name string) bool {
+	if f.Doc == nil {
+		return false
+	}
+	for _, c := range f.Doc.List {
+		if strings.TrimSpace(c.Text) == directivePrefix+name {
+			return true
+		}
+	}
+	return false
+}
+
+func methodsOf(path, id string, iface string, srcDir string) ([]Method, string, error) {
+	var err error
+
+	if len(id) == 0 {
+		// Locate the interface.
+		if path, id, err = findInterface(iface, srcDir); err != nil {
+			return nil, "", err
+		}
+	}
+
+	// Parse the package and find the interface declaration.
+	p, file, spec, err := typeSpec(path, id, srcDir)
+	if err != nil {
+		return nil, "", fmt.Errorf("interface %s not found: %s", iface, err)
+	}
+	idecl, ok := spec.Type.(*ast.InterfaceType)
+	if !ok {
+		return nil, "", fmt.Errorf("not an interface: %s", iface)
+	}
+	srcPath := p.Position(idecl.Pos()).Filename
+	if idecl.Methods == nil {
+		return nil, srcPath, nil
+	}
+
+	fns := make([]Method, 0, len(idecl.Methods.List))
+	for _, fndecl := range idecl.Methods.List {
+		if len(fndecl.Names) == 0 {
+			// Embedded interface: recurse
+			embedded, _, err := methodsOf("", "", p.fullType(fndecl.Type), srcDir)
+			if err != nil {
+				return nil, srcPath, err
+			}
+			fns = append(fns, embedded...)
+			continue
+		}
+
+		fn := p.funcsig(file, fndecl)
+		fns = append(fns, fn)
+	}
+	return fns, srcPath, nil
+}
@@ -0,0 +1,68 @@
+package generator
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMethodDocs(t *testing.T) {
+	iface, _ := loadTestService(t, "docsvc")
+	type param struct {
+		Name, Doc, Validate string
+	}
+	params := func(list []Param) []param {
+		var res []param
+		for _, p := range list {
+			res = append(res, param{p.Name, p.Doc, p.Validate})
+		}
+		return res
+	}
+	if n := len(iface.Methods); n != 3 {
+		t.Fatalf("got %d methods, want 3", n)
+	}
+	put, ping, drop := iface.Methods[0], iface.Methods[1], iface.Methods[2]
+
+	if want := "Put stores the value.\n\nIt replaces the previous one."; put.Doc != want {
+		t.Errorf("Put doc = %q, want %q", put.Doc, want)
+	}
+	if !put.Context || !put.Idempotent || put.Oneway {
+		t.Errorf("Put: context %v, idempotent %v, oneway %v, want true, true, false",
+			put.Context, put.Idempotent, put.Oneway)
+	}
+	wantParams := []param{
+		{"key", "key of the value", "required,max=64"},
+		{"value", "raw value", ""},
+	}
+	if got := params(put.Params); !reflect.DeepEqual(got, wantParams) {
+		t.Errorf("Put params = %v, want %v", got, wantParams)
+	}
+	wantRes := []param{
+		{"version", "version of the stored value", ""},
+		{"err", "", ""},
+	}
+	if got := params(put.Res); !reflect.DeepEqual(got, wantRes) {
+		t.Errorf("Put results = %v, want %v", got, wantRes)
+	}
+
+	if want := "Ping checks the store."; ping.Doc != want {
+		t.Errorf("Ping doc = %q, want %q", ping.Doc, want)
+	}
+	if got := params(ping.Res); !reflect.DeepEqual(got, []param{{"", "", ""}}) {
+		t.Errorf("Ping results = %v, want an undocumented one", got)
+	}
+	if len(drop.Doc) > 0 || !drop.Oneway {
+		t.Errorf("Drop: doc %q, oneway %v, want no doc and oneway", drop.Doc, drop.Oneway)
+	}
+}
+
+func TestComment(t *testing.T) {
+	tests := map[string]string{
+		"Line.":            "// Line.",
+		"First.\n\nThird.": "// First.\n//\n// Third.",
+	}
+	for text, want := range tests {
+		if got := comment(text); got != want {
+			t.Errorf("comment(%q) = %q, want %q", text, got, want)
+		}
+	}
+}
@@ -0,0 +1,306 @@
+// Code generated by go-bindata.
+// sources:
+// templates/client_rpc_go.tpl
+// templates/handler_nethttp_go.tpl
+// templates/handler_rpc_go.tpl
+// templates/models_go.tpl
+// DO NOT EDIT!
+
+package generator
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+func bindataRead(data []byte, name string) ([]byte, error) {
+	gz, err := gzip.NewReader(bytes.NewBuffer(data))
+	if err != nil {
+		return nil, fmt.Errorf("Read %q: %v", name, err)
+	}
+
+	var buf bytes.Buffer
+	_, err = io.Copy(&buf, gz)
+	clErr := gz.Close()
+
+	if err != nil {
+		return nil, fmt.Errorf("Read %q: %v", name, err)
+	}
+	if clErr != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+type asset struct {
+	bytes []byte
+	info  os.FileInfo
+}
+
+type bindataFileInfo struct {
+	name    string
+	size    int64
+	mode    os.FileMode
+	modTime time.Time
+}
+
+func (fi bindataFileInfo) Name() string {
+	return fi.name
+}
+func (fi bindataFileInfo) Size() int64 {
+	return fi.size
+}
+func (fi bindataFileInfo) Mode() os.FileMode {
+	return fi.mode
+}
+func (fi bindataFileInfo) ModTime() time.Time {
+	return fi.modTime
+}
+func (fi bindataFileInfo) IsDir() bool {
+	return false
+}
+func (fi bindataFileInfo) Sys() interface{} {
+	return nil
+}
+
+var _templatesClient_rpc_goTpl = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xcc\x7c\x5b\x73\x1b\xb9\xb1\xf0\xf3\xcc\xaf\xe8\x65\xd9\x0a\xe9\x8c\x47\xbb\x79\xf8\xaa\xa2\x5d\x6d\x95\x63\x7b\xbf\x75\x52\xbe\x1c\xd9\x49\x4e\x95\xcb\xc5\x40\x33\x18\x11\xd1\x70\x40\x03\xa0\x64\x1e\x86\xff\xfd\x54\x77\x03\x03\x0c\x45\x52\x94\xf7\x52\x47\x2f\x22\x67\x80\x46\xa3\xef\xdd\x68\xf0\xf4\x14\x9e\xeb\x5a\xc2\x95\xec\xa4\x11\x4e\xd6\x70\xb9\x82\xb9\xb4\xb3\x8b\x77\xcf\x4b\x78\xf1\x16\xde\xbc\xfd\x00\x2f\x5f\xbc\xfa\x50\xe6\xa7\xa7\xf0\xac\x6d\xa1\x9a\x89\xee\x4a\x5a\x98\x2f\xad\x83\x4b\x09\xb5\xee\x24\xa8\x0e\xaa\xa5\x75\x7a\x0e\x55\xab\x64\xe7\xc0\xcd\x84\x03\x3b\xd3\xcb\xb6\x06\xa9\xdc\x4c\x1a\x90\xf3\x4b\x59\x83\x36\x70\x6b\xc4\x02\xdc\x4c\xd9\x32\xcf\x17\xa2\xba\x16\x57\x12\xd6\xeb\xf2\x1d\x7f\x7c\x23\xe6\x72\xb3\xc9\x73\x35\x5f\x68\xe3\x60\x9c\x67\xa3\xcb\x95\x93\x76\x94\x67\xa3\x4a\x77\x4e\x7e\x71\xf4\xd1\xac\x16\x4e\x9f\x1a\xd1\xd5\xf8\x55\x76\x95\xae\x55\x77\x75\x3a\x93\x5f\x06\xdf\xff\x6d\x75\x47\x0f\x8c\xd1\x86\x80\x34\x73\x02\xa0\xf4\xa9\xd2\x4b\xa7\x5a\xfc\xd2\x49\x77\x3a\x73\x6e\x81\x9f\xad\x33\xaa\xbb\xa2\xa1\x4e\xcd\xe5\x28\xcf\xd6\xeb\xf2\x39\x6d\xeb\x15\xe1\x64\xff\xa2\xeb\x15\xa2\x98\x8d\xae\x94\x9b\x2d\x2f\xcb\x4a\xcf\x4f\x85\x75\x46\x20\x1c\x4f\xbd\x53\xd5\x39\x69\x2a\xb9\x70\xa3\x7b\x06\x1a\xe9\xcc\xea\xbe\x41\xce\x88\x4a\x8e\xf2\x49\x8e\x7c\x58\xaf\xcb\x9f\xa4\x70\x4b\x23\xdf\x19\xd9\xa8\x2f\x9b\xcd\x7b\x69\x6e\x54\x25\x19\x4d\x90\x5f\x9c\xec\x6a\xbb\x7f\x1c\xdc\x2a\x37\x03\x4f\xce\xa7\xe2\x56\x18\x09\x73\xe9\x66\xba\x86\x1b\x61\x94\xe8\x9c\x2d\x70\x21\x62\xa3\xa8\x70\x17\x50\x89\xb6\x05\xbd\x70\x4a\x77\xb6\x00\x59\x5e\x95\x3b\xe0\x3f\x17\x6d\xfb\x41\xcd\xa5\x5e\xba\x02\x44\x57\x83\xb0\xab\xae\x9a\x19\xdd\xe9\xa5\x05\xdd\x49\xcb\x30\x8d\x74\x4b\xd3\x41\xb3\xc4\xc9\xb6\xcc\xdd\x6a\x21\xef\xdd\x16\x51\xb4\x11\x95\x84\x75\x9e\xed\x1d\x9c\xaf\xd7\x06\x45\x14\xca\xd7\xb4\x23\xbb\xd9\xe4\x19\x13\x8d\x65\xeb\x39\x6f\x1b\x94\x8d\xcf\x98\x20\x22\x90\x84\x50\x4f\x37\x5c\xb2\x14\xf0\xdb\xf7\xea\xaa\xa3\x95\x61\xbc\x30\xaa\x73\x0d\x8c\x1e\x5b\xdc\xf9\x5b\x1a\x3c\x82\x47\x43\xd4\x26\xdb\x18\x3c\x43\xa2\x80\x25\x1e\xb9\x99\x8c\x2f\x78\x4d\xd5\xd1\xd3\x4b\x51\x5d\x5f\x19\xbd\xec\x6a\x5e\x9d\x66\x7d\xcd\xda\xeb\xf5\x53\x90\x5d\x4d\x22\x7b\x7a\x0a\x7f\x11\xae\x9a\x79\x0e\x58\x10\x70\xb9\x54\x6d\x2d\x0d\xe8\x86\x96\xf7\x1c\x12\x46\x22\x86\x0e\x9c\x26\x6c\xac\x97\x1c\xd5\x81\x00\xab\xba\xab\x56\x82\x91\x9f\x97\xd2\xba\x32\xcf\x08\xe6\x78\x02\x4f\xee\xb2\x85\x5e\xd1\xc2\x6f\x97\xee\x52\x7f\x39\x6a\xe5\xcf\x4b\xb9\x94\x75\xa0\x84\xe6\x89\xba\xa1\x6f\xde\xc4\x44\xce\x30\xdc\xdd\xab\xf3\xbb\x7c\xb3\x47\x6f\x7e\x22\x01\x44\x51\x50\xf3\x45\x2b\xe7\xb2\xf3\x36\xd0\x4b\xa6\x47\x96\x9f\x31\xdb\x58\x4f\x2c\x62\x73\x8f\xc0\xee\x15\xeb\xb0\x6a\x2a\xcf\xa7\xa7\xf0\x42\x77\x32\xa1\x0e\x5a\xda\x4e\xb6\x4c\x14\x65\xa1\x6a\xb5\x95\x35\xdc\xce\x24\x53\x85\x64\xa5\xd2\x88\xb7\x43\x1d\xca\x70\xfe\x78\x02\x3f\x3c\xc5\x99\x60\x9d\x59\x56\x6e\xcd\xa2\xf7\xd2\x18\xb8\x6c\x75\x75\x6d\x61\xd9\x39\xd5\xee\x00\x40\x32\x1f\x56\x57\xce\x02\x99\xcc\x02\x54\x03\xa2\x5b\x95\x79\xf6\xd2\x98\xf1\x84\x9f\xee\xa5\xe7\x3f\x85\x72\xe8\x25\x06\x6b\xe1\x32\x81\x9e\x61\x39\xd0\x86\x71\x88\xca\x88\x9e\xa4\x84\x57\xc1\x38\x58\x36\x40\x12\x1a\x65\xac\xe3\x75\x7b\x11\x20\x69\x51\x1d\x68\xe3\xe5\xc7\xc3\x2f\xb6\xe1\xd2\xb4\x32\x6f\x96\x5d\xb5\x1f\xdd\x71\xe5\xbe\x84\x19\x41\xc9\x8b\x1e\xe5\xb2\x2c\xf7\xb1\xd0\x93\x03\xf9\xd7\x68\x03\xd3\x02\x1a\x38\x3b\x07\xb6\x3f\x61\xfe\x3a\xcf\x32\x2b\x5b\x59\x39\xfa\x58\x09\x2b\xe1\x87\xa7\x4d\xc9\xec\x3a\x8b\x8f\x2a\xf7\x25\x79\x98\x79\x1b\x89\x4f\x89\xf4\x79\x96\x6d\xf2\x6c\x73\xdf\x52\xaa\x41\xa4\xf0\x5d\xc3\xf3\xbe\xa7\xef\xdf\x9c\x43\xa7\x5a\x58\x27\x90\xa5\x31\x3d\x4c\xff\xa8\x53\xed\x5e\xde\xb2\xdd\xa8\x74\x8b\x5b\xb1\x5f\x69\x2b\x50\x8f\xde\x4b\x34\x69\xa7\xa7\xf0\x3a\xaa\x12\x1b\x3c\x5c\x40\xd4\xb5\x87\x1d\x05\x32\xb2\x97\xd6\x8b\x42\xd4\x55\x32\x99\xaa\x2c\xd8\x43\x7a\xc7\x3b\x60\xc5\x40\x4a\x78\x4b\x42\x76\xe3\xe2\xdd\x73\xd6\xda\x77\x46\xdd\x08\x17\xe2\x90\x8c\x51\x01\xf8\xf8\x69\xef\x20\x02\x8b\x36\x18\xc9\x68\x75\x7b\x23\xe1\xe3\x27\x94\xb8\x71\x2d\x9c\x00\x0c\x41\xca\x0b\x71\xfb\x5a\x5a\x2b\xae\x64\x41\xec\x20\xb9\x99\x20\xad\x03\xae\xf7\xc0\x4e\xd0\x66\xb2\x01\x07\x2a\x40\x7f\xff\xc2\x45\xce\x46\x6c\x9b\x46\xff\xca\xb3\x77\xc2\x88\xb9\x8d\x26\x66\xbd\x09\x63\x16\xf4\xa6\xd0\x73\xe5\xe4\x7c\xe1\x56\xa3\x7f\x1d\x89\xc5\x85\xb4\xcb\xd6\x25\x78\xbc\x77\xc2\x2d\x69\x0d\x48\xff\xfc\x3a\x96\xde\x22\x2e\x2f\x90\x0a\xb0\x4d\x87\x30\x0e\x69\x84\xa3\x5e\x52\x84\x06\x1f\x3f\xa5\xfb\xea\xa1\xf9\xf8\x8d\x70\x25\x65\x1e\x1b\x78\x72\x0c\xbe\xa4\xa2\xe3\x44\x51\x55\x03\xa6\xf4\xa8\xff\x78\x0e\x7f\xfa\xf6\x5b\x38\x39\x89\x8f\x7e\x38\x87\x3f\xfd\xf9\xcf\xa4\x27\x89\x4e\x24\x2a\x72\xd2\x07\x76\x7e\x0a\x21\x8e\xe3\xf9\xeb\x59\x0f\xab\xc8\x33\xdc\xd5\x19\x00\x40\x33\x77\x25\x0d\x6c\xc6\xa3\xa0\x1e\x8c\xd2\xe3\xfa\x0c\x1e\xdb\x51\x11\xa7\x79\xce\xda\xf2\xaf\x5a\x75\x63\xc3\xf3\x6c\x01\xa3\xef\x61\x34\x99\x14\x88\x0c\xeb\x28\x2a\x92\x0f\x23\xc8\x94\x93\xa8\x0e\x94\x69\x87\x06\x16\x83\xc0\x06\xc4\x62\xd1\xae\x82\xe2\xfa\x21\x08\x5b\x58\x10\x70\x3b\xd3\xad\xe4\x40\xce\x6a\xa8\x35\xf4\x7b\xd7\x26\x28\xa3\x95\x12\x94\xe3\xf1\x0c\x99\x11\x18\x4d\xa7\x84\xc3\x74\x3a\xf2\x3e\x93\x74\xfe\x43\x5c\x06\xf5\xd5\x48\x67\x14\xbb\x57\x46\xc0\x99\x15\x2c\x74\xab\xaa\x15\x79\x9e\x7e\x5b\xc2\x48\x50\xb5\x9c\x2f\xb4\x43\x15\x87\x67\x9d\xa7\x9f\x22\x47\xd1\xfb\x69\xd5\xa4\x3b\x81\x46\xa8\x96\x30\x95\xdd\xc0\x0f\xe1\x73\x0e\xfa\x94\x03\xa7\x75\xc1\xd0\x88\x7c\x4c\x2f\x84\x1a\x97\xd6\x5d\xbb\x02\x23\x31\xfe\xef\xb1\x55\x26\x80\x2b\x99\x15\x46\x5a\xe9\x6c\x24\x7f\x81\x54\x53\x0e\xe6\x62\x05\x97\x12\x8c\x5c\x5a\x59\x23\x5c\xb4\xe0\x9a\xb2\x22\x5a\xc1\xfb\xa7\xf1\xf4\x72\x6f\x00\x35\xa1\x15\x76\x3b\x2a\xbd\x70\x7b\xbc\x54\x0c\x0c\x13\x05\xa0\x25\x0b\x08\xa6\xea\xec\x1c\xa6\x97\xa5\x7f\x38\xbd\x2c\xfd\xf3\x3c\xdb\xf5\x14\xc8\x8b\x14\xac\x13\xaa\x81\x56\x76\x63\x1a\x34\x81\xf3\x73\xf8\x76\x97\xda\x44\xa6\xe1\x52\xce\x2c\x65\xef\xc1\x70\x66\x74\x62\x4c\x6c\x72\x61\x71\xca\x79\xc2\x74\xd4\xd3\xbd\x2a\xff\xaa\x1f\xf6\x11\x01\xf9\xe0\xff\x13\xa3\xd0\xa4\x50\x70\x05\x14\x70\xaf\x22\x7a\xf0\xd2\x47\x16\x16\xac\x68\x24\x38\x8d\x3c\x97\x82\x44\x24\xcf\x32\xa2\xf4\x39\xea\x0c\x32\xe3\xe3\xa7\x43\x14\x5f\x03\xc6\xdd\x7b\xb0\xc5\x61\x11\xe3\x0d\xf3\xb0\x2c\xcb\x09\xe1\x7b\x23\x0c\xb2\x67\xd9\x3a\x7b\xaf\xdf\x61\x2b\x97\x67\xde\xe7\x23\xd3\x68\x64\xa9\xba\x1b\x7d\x2d\x51\x62\x8a\x54\x17\x99\xea\xb6\x80\x13\xbf\x02\xaf\x3d\xc9\x43\xe0\x70\x4e\x2c\x46\x5a\x23\x73\xfd\xa0\x09\xc6\x0f\x09\xb3\x91\x86\x34\x78\x60\xd8\xf6\x62\x7a\x06\x57\xda\xc1\xe3\xba\xdf\x15\x0a\x40\xc2\x80\xc7\xde\xe5\x8f\x8a\xc1\xa2\x45\xb2\xe4\xa4\x0f\x7d\x54\x01\x4d\x17\xc5\x26\x48\x66\x12\xfb\xa4\xb1\x4e\xd3\x8d\x49\x62\xa5\x31\x18\x41\x65\xa8\x3d\xaa\x43\x21\x44\x78\x59\xd3\xaf\xf6\x51\x7d\x2a\xd1\x53\x15\x90\x3c\x20\xdf\x31\x49\xcd\xbf\x34\xfb\xa3\x5f\x9f\xe0\x50\xf2\x62\x63\x90\x7a\x5f\x0a\x53\xc0\xed\x4c\x55\x33\xa8\x65\xab\x6e\xa4\x21\x03\x32\xc7\x15\xee\x24\x82\x21\x24\x32\x4a\xa6\x81\x7c\x70\x28\x9c\xa9\xf3\xfc\x12\xde\xa2\xc9\x0a\xd9\x4a\x92\x75\x23\xe4\x4e\xf7\xac\x10\x0b\x61\x1c\x34\x46\xcf\x41\x04\x9b\xea\x0d\x16\x67\x61\xa9\x69\xf4\x28\xca\x3a\x31\x8d\x9d\x76\x89\x65\x14\xd5\xf5\xde\xf8\xcb\xd3\xe7\x4e\x00\x76\x20\xfe\x3a\x4c\xea\xff\x42\x04\x61\x21\x8d\x55\x36\x84\xa4\x64\x74\xdd\x4c\xae\x08\xb9\x88\xb0\xbc\x91\x5d\xf0\x0e\x0b\xa3\x2b\x69\x2d\xd2\xc0\x09\xe3\x8b\x1d\x54\xd2\x78\xc2\x8c\x2a\xdf\xf6\xfc\x3a\x54\x95\xf1\x83\x0f\xef\x97\x91\x1c\x24\x7b\x2f\x3b\x22\xed\xb8\xe9\x70\x9b\xde\xdf\x17\x30\x93\xa2\x96\x06\xb0\x12\x55\xfe\x4c\x9f\x0b\xb8\xd4\xf5\x0a\x3e\x7e\xc2\x1a\x58\x92\x7c\x79\x7f\xa1\xf7\xa7\xbc\x13\x90\xbb\x17\xb9\x49\x83\xc2\xc4\x2d\xd0\x60\x32\x21\x3a\x98\x10\xbd\x70\x9e\x10\x64\x1d\x78\xc4\x79\x54\xae\xa8\x12\xda\xd8\xf2\x8d\xbc\x3d\x68\x02\xbc\x0a\x28\x4b\x12\x53\xe9\xae\x51\x57\x4b\x23\xeb\x11\xeb\x57\x4d\xba\xe7\xcd\x18\xc5\x8a\xaf\x85\xb1\x33\xd1\x8e\x6f\x26\xf9\x0e\xcd\x7e\x90\xf9\x41\x77\x2f\x6b\x70\x1a\xa8\x32\xd8\xc7\x07\x67\xf0\xf8\x66\xd4\x1b\x87\x34\x2f\xda\xe4\x99\x67\xc7\xd9\x39\xcc\xc5\xb5\x1c\x27\x6c\x99\x84\x97\xe5\x7b\xe9\xc6\xa3\xff\x7e\xfa\x9a\x05\xe2\xe9\x4f\xaa\xbb\x92\x86\x2a\x33\xa3\xe2\xbe\xfa\x40\x32\x78\x42\x59\x7a\x62\x28\x7a\x5b\xe0\xf5\xcc\x41\x2b\x85\x75\x94\xf1\x04\x09\xef\xf5\xbe\x93\x12\x43\x40\xb8\x96\x1c\xc9\xc9\xb6\x05\x23\x3d\x08\x25\xed\x10\xdb\xde\xef\x54\xab\xa7\x7f\x93\x2b\xc6\x73\x37\xe1\xde\xc8\xdb\x64\xf4\xdf\xe4\x8a\x8c\xa1\x27\x13\x89\x43\x39\x14\xe5\x20\xc3\x05\x20\x3b\x27\x7b\xd5\x37\xba\xc9\x28\x08\x36\x86\xaa\x21\x8e\xfc\xda\x02\x4b\x02\x9e\xf2\x30\xbd\x38\x64\x64\xe2\x68\x3b\xb9\x2f\x13\x4a\xc6\x26\x76\xcc\x71\xc9\x13\x00\x00\x3f\x96\x2f\x96\x46\xe0\x98\x5e\x86\xf0\x2f\x91\x9f\x3c\x73\xc2\x5c\x49\x9a\xe0\x15\x93\xc8\x4a\x76\x1d\x00\x35\x34\xcf\xac\x74\x17\xfe\xd1\xa5\xd6\xed\x20\x92\xe2\x07\xc8\x6f\x80\x14\xc8\x21\x7a\xfb\xba\x2c\xb4\x6a\xae\x7c\x94\x5a\x7b\x3c\xd3\xba\x4a\x01\xaa\xab\xda\x25\x56\xcf\x83\xab\xd9\x5b\x3f\x49\xc0\x8e\x03\x0d\x06\x04\x98\x1c\x66\xce\xba\x17\xa6\x07\xb2\x09\xd6\x1c\x8d\x95\x61\xd5\x73\xf0\x9f\x62\x76\xb4\x7b\x65\x66\x00\x56\x19\x2c\xf9\xbb\x2f\xce\x88\x60\x78\x9d\xee\xa9\x10\x6b\x9b\x07\xb6\xce\xb0\xc6\xd7\x72\x55\xc0\x8d\x68\x97\xc1\xc8\xfe\xa6\xbb\x56\x0d\xc6\x0d\xa5\x47\x39\xb1\xc6\x59\xfa\x78\x97\xc9\xa2\x80\x27\x0e\x2a\x9f\xd5\x75\x82\xfb\xe4\x3e\xca\x7d\x60\x91\x8d\x65\x6b\xa2\x53\x28\xf9\x2c\x64\xa5\x1a\x55\x81\xea\xac\x13\x5d\x25\x83\x48\x79\x1b\x55\x70\x3c\x63\x9d\x14\x35\xbe\x12\xd0\x6a\x51\x3f\xbd\x14\x2d\x0e\xae\x81\x6a\x7f\xef\xa5\x84\xaa\x5d\x5a\x27\x4d\xc9\xab\x31\xee\x87\xe5\x8f\x06\x8e\xfb\x75\x7f\x07\x16\x90\xe0\x31\x35\xce\xfb\x0d\xdf\x47\xbe\xa0\xcb\xfa\x46\x1a\xa3\x6a\xc9\x34\xec\x96\xf3\x4b\xae\x60\x06\xf5\x1f\xa8\x22\x25\x89\xb7\xca\x4a\xb0\xd2\x0d\x92\x64\x5c\xc8\xe7\xc9\xc3\xa0\x92\x42\xe4\x68\x29\x42\x00\x18\x4e\x34\xac\x3f\xea\xe8\xe2\xa0\x6a\x05\x24\x06\x38\xe4\x7f\xa4\xd1\x31\x3d\xb5\x7d\xca\xce\x07\x13\x31\x5f\x5f\x2e\x74\x70\xfc\xd0\x2a\x1b\x93\x62\x8f\x54\x11\x92\x6b\x4b\x21\xa1\x6e\x9a\x12\x2e\xfc\x5c\x46\x03\xa9\x27\x28\x18\xbd\x83\xc8\xb2\x6b\xa5\xb5\x1c\xc4\xcd\xc4\x8d\x44\xe9\xb8\xe3\xf4\x6a\x2d\x6d\xf7\x07\x47\x82\x8e\x2f\xe6\xe0\x6e\x55\x25\x0f\xca\x8a\xe7\xc1\x38\xd0\x1a\x3d\xef\x6f\x2d\x28\x61\xad\xf3\xc0\x61\xff\x3c\x31\xef\x21\x2d\x3e\x2c\x40\x43\x4f\xbc\xad\x87\x44\x6e\xfc\x76\x87\x9a\xf8\x70\x26\xba\xba\x95\x66\x4b\x29\xb9\x70\xb2\x68\xc5\xca\x7a\xc1\xb2\x0b\xdd\x59\x99\xca\x60\x04\x6c\xc5\x5c\x22\xc4\x54\x8d\xe7\xe2\x1a\xfd\x84\x72\x20\xae\x84\xea\x0a\x10\x16\x5a\xdd\x5d\xe1\x7f\xe5\xe0\x5a\xca\x45\x5f\xc7\xbf\x96\xab\x12\x9e\x27\x22\x08\xd7\x3e\x48\x3f\x54\x04\x1a\x08\x77\x01\xad\xba\x96\xa9\x78\xeb\x4e\x46\x21\x45\x78\xe1\x5c\x7a\xd9\xa9\xcf\x9c\x1b\x78\x55\xa2\xf0\x1e\x41\xbd\x7a\x11\x80\xea\x85\xf4\xfe\xcf\x25\x22\x3e\x17\xb5\x44\x15\xf0\x12\x27\x5c\xd8\x40\x18\xe0\x13\x1e\x29\xdc\x56\x5a\xe1\x35\x30\x64\x15\x07\x45\x71\x2b\xae\x42\xd4\x7f\x27\xcb\x85\x4b\x9d\x23\xad\x06\x02\x77\x64\x00\x98\x9c\x4f\xe1\xd9\xbb\x9e\x6f\xcb\x5b\xdc\xf4\xd1\x21\xa5\xdf\x38\x22\x88\xb8\x85\x78\x9b\x93\x9e\x02\xbe\xfb\x7f\x18\x73\x8a\xae\x2e\x2f\xa4\x20\x8f\x15\x63\xd0\x99\xfc\x52\xbe\xa4\x88\xfe\x83\x7e\x4f\x50\xf8\xfd\x3d\xdb\x7a\x15\x05\xa8\xa5\xcc\x31\x58\xc9\xb9\x30\xd7\xb2\x66\xf9\x3c\xa5\x34\xcf\x2c\xaa\xb3\x28\x6f\xfe\x54\x5c\x2a\x93\x24\xc0\x47\x8b\x6f\x99\x63\x65\xe7\x18\xac\x90\x04\x8b\x8f\x4c\x97\x4f\x18\xec\xad\xe9\x14\x77\xfb\x60\x7b\xbd\x56\x0d\x94\x71\x1e\x1e\x58\x8c\xfa\xd3\xe4\xd1\x19\xd9\x95\x22\x1e\x00\xaf\xd7\xf4\xef\x3e\xf2\x0c\xab\x53\x44\x94\xbe\xba\x2b\xb6\x4b\x63\xf7\x31\x7c\x08\xec\x81\x02\x8b\xf2\x3a\xa8\x06\x92\xa1\xdc\x1c\xd3\x05\x91\x64\x57\xa8\xb4\x02\x66\xc2\xce\x02\x3f\x0e\x75\x46\x18\xd2\xfe\x79\xd0\x7b\x3a\x79\x45\x80\x08\x00\x2e\xa5\xec\x92\x6e\x99\x46\x1b\x3a\xb2\xf4\x27\x4f\x20\xc8\xf8\x49\x51\xcd\xbc\xd5\xb1\x3a\xd8\x5e\x96\x15\x71\xd9\x22\xed\x10\x74\x2d\x9d\xac\x9c\x07\x6e\x53\xa0\x5c\x8b\xe1\x02\x31\x26\x81\x49\x84\x1e\x8f\xd6\x7c\x0e\x5f\xe6\x95\xee\xac\x7b\x08\x35\xce\x01\x65\x24\x79\xb2\xd9\x8c\x8e\xa1\xe8\xcf\xbc\x11\xe4\x14\x6e\x98\xc2\x17\xfc\xac\x43\xc9\x7d\xae\x9d\x84\x8b\x77\xcf\xc3\x96\x8b\xa4\xf8\x1d\x4a\xdf\x7c\x4e\xe0\x83\x3c\x0f\xf1\xfd\x42\x56\x78\xae\x6d\xf8\x30\x2f\x34\x05\xf5\x9e\xc7\x83\x0b\xb6\x97\x5b\x83\x64\x7d\xe4\xd6\x53\xb4\x79\xeb\x17\xef\x9e\xfb\x87\x03\xe1\x63\x22\xbc\x34\x66\xc7\x71\x6f\x24\xd6\x6b\x65\xe7\xe1\xb4\x91\xbd\xc2\x92\x3d\xa6\x8f\x86\xfa\x93\x88\xfe\xa0\xde\x13\x26\x71\xba\xbb\x24\xe9\x21\x4c\x47\xb7\xd4\xa5\xb2\xc9\x96\xe5\x68\xd4\xcf\x07\x35\x9b\x08\xb6\x89\x63\x61\xee\x07\x8f\x26\x87\x8d\x45\x02\x9f\x0a\x31\xd4\xdf\x65\x8f\xc7\x85\xb8\x7c\xa9\xdd\x2c\x5d\x3d\x69\x09\x3a\x72\xd5\xbb\xa7\xba\x21\xaf\x0e\xd4\x8b\x29\x32\x57\xcf\x24\x3c\x39\x1a\xfc\x04\xe8\xdf\xc0\x57\x05\x37\x3c\x77\xe5\x7b\x1a\xd9\x8c\x47\x7e\xed\xc7\x58\x85\xf4\xcb\x3e\xb6\xa1\xc8\x54\x86\x20\x46\x96\xfe\x65\x71\x34\x99\x82\x4b\x7b\x4e\xd2\x16\x9c\xf0\xb1\xb3\x0b\x3a\x32\x17\x4e\xa1\xf5\x21\x82\x27\x55\xcd\xc5\xf5\xd5\xa9\x17\x87\xaf\xa2\x0c\xa1\x94\x9e\xb0\x7a\xc2\x1c\x8b\x9c\xdf\xd9\xdf\x3b\x14\x9c\x5f\x61\x6b\x7e\x2f\xaf\xbe\x72\x3b\x8c\xc7\x2f\xdc\xcf\x31\xfd\x6c\x77\xcb\x48\xa7\xa7\xf0\x2a\x39\x60\x25\x4d\xf2\x71\x06\x05\xa5\x3e\xc0\x08\x62\x14\x9b\x63\xa8\x07\xd3\x86\xfa\xa1\x34\x73\x4d\x5d\x59\xdb\xf0\xd0\x14\x63\x7b\x05\xe7\xff\x16\x9c\xa6\x2c\x22\xad\x7b\x94\x79\x36\x98\xf2\xf1\x53\x3c\xee\x4e\x5e\xa4\xfd\x5c\x83\xe3\x0e\xc2\xb3\x37\xdc\x7e\x44\xd2\xe7\x95\xe0\x4f\x30\x94\xe3\x88\xdd\x17\x2b\x7d\x22\xa7\x63\xc2\xe1\x35\xa5\x6f\xf3\x3a\x5c\x66\x27\x98\x98\x56\xad\xde\x71\xfc\x15\xb2\xaf\xfe\x6c\xfc\x6e\x6a\x5c\x1c\x11\xf5\x65\x7c\xc4\x6c\x56\xe5\x0b\xd9\x88\x65\xeb\x3c\x78\x65\xc9\xb1\x81\x6a\xc8\x33\x59\xe9\x42\x8a\xa3\x1b\x7f\xc2\xdb\x27\xe0\xf1\x14\x99\xc2\x45\x82\x49\x4b\x26\x99\x69\x11\x78\xec\x93\x66\xca\xc9\xe3\xf1\x4b\xcc\xaf\xcb\x3c\x4b\xb7\xf9\x84\x91\xe3\x6f\xbd\x89\xab\x66\xb2\xba\x3e\x4e\x0a\xfb\xb8\xec\x88\xb1\x93\x63\x07\xfa\x76\x0b\x04\x9d\xd4\xa9\xe8\x2b\x9c\x1c\x07\x62\xbd\x09\x27\xb8\x18\x0a\xa6\x5b\x1e\x42\x1c\xbe\xda\xc5\xab\xf4\x10\x4f\x2f\xdc\x01\x2d\xfd\xf9\xc3\x87\x77\xbb\x5a\x4e\x5f\xe8\xb1\x91\x9f\xe1\x09\x55\xd5\x2e\xb8\x3e\x38\x81\x71\xf8\xce\xb9\x73\x91\xb4\xf6\x10\x13\xde\xc8\xdb\x7b\xb6\x3a\xce\x33\x04\xc1\x5f\x0e\xe2\x53\x50\x44\x7c\x2c\xf9\x8b\x7c\x72\x9f\x0d\x4a\xcc\xdb\xc9\x5e\x1b\xe9\x49\x7c\xf6\x50\x79\xc2\x56\x95\x64\x67\x67\x10\x3f\x87\x26\x96\xc3\x4e\x3e\x31\x8d\xb8\xed\x63\xf7\x7d\x34\x39\xbd\xe7\xf9\x0d\x1a\x62\xfd\xf9\xdc\xbd\xe7\x9b\x13\xb8\xaf\x73\x76\x8b\x43\xbb\x86\x20\x7f\x2a\x4f\xe2\x69\x35\xa0\xef\x6f\xd7\x75\x7b\xfc\x0e\xef\x6f\xcf\x3d\xbc\x47\x1e\x73\x60\x93\x9c\x05\xbf\x77\xa2\xab\x31\xff\xf2\xc2\xb0\xd9\xdc\xe3\x85\x07\xca\xbd\x2b\xbb\xa6\x87\x64\xa1\xcb\x17\xba\xc2\xdc\x7a\xbd\xae\xf4\x7c\x8e\x1b\x2e\x07\x7d\xd5\xd8\x9e\xdd\x77\x66\x0f\xde\x6c\x76\xb5\xa3\xaf\xd7\x4e\xce\x17\xad\x70\x12\x46\x5e\x64\xa6\x73\x5d\xcb\x76\x04\xe5\x9d\xd7\x6c\x56\x86\xef\x03\xf4\xfe\x66\xc2\x87\xd5\x42\x86\x7b\x09\x21\xc9\xdf\xb3\xa5\x64\x43\xdb\xdb\xa1\x79\x77\x18\xfb\x68\x2f\x67\xfb\xe5\x93\xbd\x73\x6f\x3e\x32\xe4\x42\xda\xcd\xc6\xb3\xd5\xc3\xf6\x50\xcb\xed\x5e\xfc\x31\xcf\xf0\xdf\x70\x9c\xfb\xb2\x5e\xcb\xd6\xca\xcd\x26\xb4\x3b\xfd\xa5\xef\x82\x18\x4f\x3c\xb8\x9e\xb4\xe8\x41\x9f\x99\x2b\xbb\xd9\xd0\x61\x66\x5f\xe8\x48\x0a\x41\xbf\xa4\xf7\x1f\x29\x89\xf8\xbd\xed\xe4\xad\x40\x12\xa7\x20\x11\x96\xa6\x17\xa1\xdd\xab\x57\x35\x0b\x56\xeb\x0e\xff\xef\xe9\xd2\xf0\x21\x57\x64\xe8\x03\x89\xff\xd5\xf7\x11\x90\x4b\x53\x74\x66\x67\xec\x89\x19\xa8\xf7\x69\xc4\xc1\x5e\x76\xb8\x93\x34\xd4\x9a\x42\x5b\x2c\xdd\xbf\x50\xb2\xad\xc3\x09\x7b\x0f\xa4\xc8\xb3\x7e\x3f\x41\x14\x43\x53\xd3\x14\xc5\x19\x92\xf5\x58\xb8\x79\x06\x82\xff\x59\x70\x3b\xe5\x86\x7d\xfe\x94\x0f\xf9\xa7\xc3\x96\xa6\x29\xf7\x34\xc5\x3a\x57\x81\x80\x3f\x17\x70\x42\xf0\x0b\x98\x52\x47\xd3\xf7\x30\xdd\x6a\x19\x60\xc6\x10\x36\x84\x22\xc9\x57\x5c\xe8\xec\xb7\x5b\x29\x58\x89\x9e\xaa\x17\x32\x90\xb4\xd3\x0e\xca\x57\x71\xdb\xeb\x75\xf9\x0f\x61\x78\x3d\xdc\x3b\x2e\x54\x0e\xa9\xbd\x8b\xc2\x7e\xc5\x2d\x79\x7f\xe8\x4d\x93\x22\xb6\x05\x19\x09\xe2\x46\xa8\x96\x2a\x56\x54\x9d\xc0\xa1\xdc\xf0\x58\x3e\x50\x50\xbf\xf6\xea\x0a\x89\x69\x33\x14\x52\xee\xbc\x47\x22\x4f\x6b\xdd\xc9\x33\x2e\x16\x0f\xae\x3c\x70\x8f\x6c\x76\xa5\xb9\x46\xce\xa5\xef\x5a\x36\xd2\xf0\x25\x8a\xf1\xb4\x29\x69\xf2\x84\x04\xb9\x37\x56\xc1\x9e\x3c\x52\x05\x3c\x22\x79\x48\xf8\xf4\x48\xb1\x75\x61\x43\xd6\x94\x29\x9f\xfc\x53\x38\xe7\xf7\xf0\x94\x54\x64\xaf\xb5\x63\xf3\x76\x8f\xed\xf2\xc2\xe5\x9b\x02\xc7\xb1\xe6\x3d\x6d\xb6\xb9\x1c\x6f\xb1\x50\x82\x20\x3b\x7f\x78\x8f\x9c\xf4\x6d\x14\x89\x38\xc4\xd2\xca\x60\x76\x8c\xb5\x88\x34\x30\xa0\x68\xbe\x2d\xba\x5b\x72\xba\x5e\x97\xe8\x82\xb6\x5c\x5f\xfe\xcb\x2f\xb5\x78\x39\x6b\x28\x78\x48\xf1\x9d\xc0\xee\xeb\x2e\x49\x2c\x11\xb8\xec\x31\xc1\xcb\x1e\x0f\xbe\x04\xe3\xd5\xe1\x20\x22\x08\x78\x9c\x78\x43\x6e\xc9\xb4\x2c\xbe\x3f\x3c\xed\xd1\xc8\x7a\x67\xf8\xcb\xc4\xcc\xef\xe7\x57\xb8\xd3\x73\x60\x53\x2f\x87\x5d\xf3\xe9\x36\x76\x19\xeb\x84\xe4\xd4\x3e\x95\xda\xd7\xb4\x21\x78\x5b\x3c\xfa\x75\xb9\x23\x63\x87\x8d\xf2\xe1\xb6\x6f\xa8\x3e\xca\x3c\xed\xbd\x17\x92\xb6\x59\x3f\xda\xd3\x67\xbd\x5e\x97\xf4\x69\x2b\xa6\xf9\x7a\x33\xf4\x3b\xfb\xd9\xd0\xb6\x1d\xfb\x94\x63\x23\xf7\x7e\x33\xdd\xdf\x33\xc1\x1d\x71\xb8\x78\x36\x70\x7c\x79\xe6\xef\x94\x9c\xb1\x0b\xcc\xb3\xcd\x84\x16\x8b\x9d\xe1\x71\x39\xff\xac\x60\xfb\x3b\xdd\x7d\x0d\x66\x1a\xef\xc1\x1c\x34\xd0\x07\x03\x87\x18\x25\x0c\xda\x96\x69\xc9\x09\xfc\xe8\x5b\xd2\x33\x1e\xc2\x38\xfc\xbd\x9b\xfb\xc6\xc2\x29\xf7\x1c\xb2\x33\x0f\x0d\x32\xaa\xb9\xe3\xcd\x77\x4a\x7c\x96\x79\x61\x47\x27\xcd\x57\xa9\x52\x5f\x1f\x63\x80\x8c\xd9\x78\x84\xeb\xcf\xb6\xf4\x7d\xaf\xfb\xdf\xc5\xf9\x2d\x07\x11\x50\xa6\xb2\x18\x2a\xc9\x66\x5b\xe1\x7c\xdd\xee\xae\xca\x15\x69\x10\xdb\xab\x52\x38\xfd\xf6\xdd\xb6\x78\xfc\x92\x76\xa1\x3e\xda\xd7\x86\xba\x5e\xfb\x1e\xd2\x2d\x85\x0a\xc8\xea\x32\x34\xaa\x0e\xe2\xac\xdf\x45\x5b\x26\xf9\x30\x9b\xe2\xff\xdc\xaa\x84\x01\x20\xf0\x41\x3e\x08\xb0\x0b\xb1\xd5\x25\xc7\x07\x59\x33\xd5\xd6\xe1\x79\x18\x53\xb9\x2f\xbe\x23\xc0\xc8\x3f\x58\x6a\x5e\x41\x90\x68\x8b\x17\xc2\x5a\x99\x76\x4f\xcd\x8c\x5e\x5e\xcd\x06\xf7\x6a\xf6\x35\x89\xd3\x1d\x16\x0e\xe5\x14\x65\x29\x7c\x88\x88\x87\xdf\x5b\xed\x1b\xaa\xa3\xd3\x59\xbb\x78\x48\x9a\x1e\xef\x0b\xec\xb8\x0a\x39\x6c\x21\xa6\x10\x18\xe1\xa7\xad\xc4\xfe\x1e\xca\xe1\x4b\x11\x13\x18\x0f\xb5\x9e\x02\x6c\x22\x1c\x5d\x0e\x11\x95\xc4\x44\xde\xb8\x71\x1f\x79\xfb\x6c\x9d\xd1\x3c\x1d\xfd\x31\x34\x9a\xf2\xe0\xbf\xa9\xae\xf6\x25\x25\x54\xd7\x49\xee\xcd\x48\x12\xf8\xa1\xfd\xb0\xe1\xa6\x16\x3c\xd9\x79\x7d\xab\xbf\x3b\x80\x27\x07\xcf\x2c\x22\x59\xc0\x49\x3f\x8b\x01\x65\x88\x27\xf6\xd0\xf2\xc4\x71\xff\xda\x43\x0a\x16\x84\x86\xfd\xa4\x3a\x65\x67\x63\xee\x2c\xa6\xf0\x2d\xdc\x76\x49\x2e\x90\x05\x83\xeb\xf7\x78\x06\x77\x77\x3c\x2a\x12\x83\x0c\x9e\x13\xf8\xec\x22\x74\x30\x03\x9b\xe3\x2c\x18\xc5\x33\x62\x0d\x3e\xe1\xee\x39\x9c\x76\xa7\x1b\x90\x5d\x13\x61\xfa\xaa\xfb\xb7\xac\x1c\x5d\xb3\x88\xbd\x82\x5e\x3b\xd3\xe2\x7f\xbc\x4e\x32\x4d\xfa\xc3\x5f\x0d\x6e\x84\xb1\x6a\x10\xf1\x77\x0a\x12\xbe\x87\x27\x43\x02\x24\x51\x46\x86\x98\x63\x19\xa3\x6f\x04\x0f\x4b\xe1\x44\x5e\x3c\xb9\xdd\xe3\xbf\x5c\xa4\x17\xdb\xfa\xc6\x79\x7f\xab\x65\xd7\x9d\x90\xed\xfb\xaf\xbd\x2d\xf2\x8b\x2d\x83\x87\xf8\xeb\xfb\xb7\x6f\xfe\x21\xda\xa5\x1c\x47\xc4\xfc\x92\x4c\xeb\x89\x37\x22\xe1\xb2\x58\x92\x6a\xe1\xdc\xa7\xdc\x6d\x5e\xf7\xd7\xd1\x7c\x30\xe3\xf5\x69\xee\x77\x41\xd5\x6a\xdf\x47\xb5\xa3\x89\x84\xec\x11\xdb\x04\x9e\x41\xf7\xd5\x93\x1e\x28\x13\x0d\x0a\x9e\x2c\xef\xea\xed\xd3\x06\x04\x9e\xce\x74\x2b\x6a\x94\xa5\xe2\x04\x4e\x40\xd8\xfd\x55\xc0\xb6\xd5\xb7\x25\x3c\x73\x74\x31\xd4\x72\x9b\x26\xef\x6a\x46\xd7\xef\x9c\xbd\x0b\xb8\xef\x03\xa2\x33\xa8\x78\x0c\xf2\x00\xcb\x13\x78\x7b\xaf\xdd\xb9\x19\xda\x9b\x5d\xd7\x25\x8e\xb3\x41\xa1\xb5\x28\xda\x21\x34\x12\x15\x0f\x39\xaa\xf9\xbb\xbf\xc3\xa6\xe3\x4d\x24\x5a\x9b\x8a\xe5\xe3\x13\x0f\x6b\x12\x4e\x2f\xfc\xf7\xbe\x5f\x39\x44\x26\xbc\x6e\x57\xc9\x36\xee\x9e\xbe\xfe\xb4\xec\x2a\x2c\x79\xb2\xc8\xd3\x80\xf3\x7e\xc8\x3f\x95\x9b\x85\xbe\xeb\x5e\x29\x12\xf0\x93\x18\x4e\xd1\xd4\xf1\xff\xb5\x2b\x16\xe1\x4a\x16\xa1\xc5\x52\x9e\x6a\xfb\xd6\x81\x4e\x9e\xd9\x5b\xe5\x6b\xe1\x74\x57\x3e\x6c\x37\xf6\x50\xe2\x8d\xf9\x45\x38\xfd\xe1\x0f\x44\xa5\x20\xcc\xe3\x30\x25\x1c\x08\xfe\x11\xbe\x9b\x78\x68\xdf\x84\x77\xc3\xab\x86\xe1\xee\xd9\x5b\xee\x99\xf3\x37\x1c\x4f\x4e\xe0\x9b\x63\x2e\x21\xb2\xe8\x7e\xba\x17\xaf\xef\x98\x35\xbe\xf1\x2d\x59\xaf\xbf\x5f\x39\x58\xdb\x83\x78\x2d\xbe\x04\x08\xf0\x23\x7c\x17\xd0\xf5\x8d\xdd\xff\x7f\xd7\x35\x8f\x49\x72\x45\x93\x5b\x00\x1f\xd2\xa3\xd7\x97\xf0\xc6\x89\x95\xf6\xf7\x92\xd0\xe5\x91\x03\x0c\x37\x17\x26\xb9\x17\x1d\x8f\xed\x0b\xcd\x52\xea\x9d\xf2\x58\x75\x2e\xd5\xbd\x8c\xe2\x89\x29\xee\x9f\x74\xf9\x8d\xbc\xf5\x26\x7d\x3c\x7a\xf7\xf6\xfd\x87\x51\x30\x04\x78\x3a\xea\xa4\xe5\x01\xd4\x7a\x4f\x61\x3e\xcb\xd5\x67\x3a\xf6\xfb\x4c\xd4\x0d\x85\x96\xca\x7d\xc1\x97\xa8\xaa\xb1\xd1\xdd\x46\x8d\xf5\x16\xc4\x3b\x85\xcf\xde\x8a\x7c\xbc\x96\xab\x4f\x31\x9f\xd9\x7a\x11\xa0\x70\x61\x26\xdb\x1c\x82\x1f\xd8\xf9\x2b\xad\x13\x47\xfc\x8a\xf7\x8e\x50\xcc\x6a\x29\xea\x56\x75\xb2\x00\x7d\x4d\x62\x88\xbf\x44\xe1\x9f\xe1\x0f\x48\xe8\x6b\xc6\xfd\xf4\x74\x50\xc9\xae\xa5\x51\x37\x3e\xf8\x0c\x20\x06\xbd\xc3\x7d\x5e\x6e\xe4\x5c\xa8\x0e\x5d\x1c\x5a\xa8\x3c\x3b\xb4\x17\x6f\xd8\x46\x05\x8d\x2d\xff\x8e\xb5\x8d\x71\x00\x3f\x29\x7d\x9b\xe7\x24\xc9\xd7\x52\x4d\xe5\xbe\xfc\x24\xf5\x3b\xb0\x10\x0d\x1d\x25\xd6\x93\xe7\x0e\x01\x93\xfa\xed\x85\x76\xf7\x32\x15\xf7\xa7\x12\x04\xd4\x97\x3e\xdc\x1d\x44\x37\xd6\xdf\xf4\x1f\xd6\xb7\x6b\x3a\x60\x4e\xcc\x64\x32\x8c\x33\xbc\xa1\x8d\x3e\x39\xf1\x23\xa2\x66\x23\x87\x8c\xe8\x2c\xfd\x78\x96\x6f\x1c\x10\x46\x72\xe2\x51\xc7\x7b\xfe\x31\xba\xa0\x56\x6d\xfb\x50\x6b\x9f\xd8\xf4\x58\xe1\x19\x44\x6f\x3e\x2a\xaa\x75\x12\x13\xf5\x3f\x41\x80\x5f\xe8\xaa\x81\xc7\xdf\xe2\xe1\x1d\x5f\x0b\xde\x46\xff\x21\x91\x44\xbd\xfb\x84\x3e\xb8\xfb\x2d\xbb\xc3\x25\xfc\xad\x40\x33\x1e\x1d\x97\x2f\x02\x3b\xee\x7a\xc6\xd4\x8d\x7d\x1b\x3d\x59\x24\x00\x59\x33\xfe\x81\x31\x6a\x5b\xc6\x5f\x99\xc1\xb7\x25\xbe\xc6\xc2\x09\x59\x2b\xff\xbd\x7c\x4e\x45\x0f\x5e\x29\xed\xc1\x3b\xf3\x83\x7e\x4e\xec\xfa\x6e\xa5\x9f\x7c\xcf\x23\x39\x13\xa1\xdf\x74\x3b\xf7\xe6\x94\x1f\xbd\x33\xb2\xd2\x5d\xad\x30\x7a\xf9\x89\x5d\xf5\xc9\x49\x9e\x65\x28\xe2\xc9\x92\x2c\xea\x27\x27\x03\x34\xbe\x39\x7f\x80\x49\xe9\x23\x07\x5f\xf2\x3a\xae\xef\x8a\x94\x2b\x9c\xef\x3e\x60\x35\x4c\x73\x42\x87\xdf\x19\x34\xc3\x17\x9b\x2d\x4e\x6d\x51\x28\xf2\x4d\x35\xdb\xef\xe0\x07\xfa\x65\x90\xff\xfc\xe7\xce\x8b\x1f\xfb\xdf\x07\x09\x9b\xdc\xfb\x83\x20\xf1\x17\x41\xb6\x16\xce\xb3\x87\xfd\x32\xc8\x36\xde\xc9\x83\x49\x31\x34\x57\x41\x04\x13\x9b\x25\x0d\xfd\x86\x08\x9c\x7f\xd5\x5a\x1c\x7c\x47\xb8\x93\x87\x10\xf6\x8e\x61\xb8\x33\x32\xfe\xe2\xd0\xdd\xac\x6b\x50\xdd\xa0\x22\x62\x83\xc5\x1d\x4e\x4c\x70\x54\x2c\x79\xc8\xee\x46\xb6\x7a\xe1\x6b\x1f\x37\x0f\x31\x1a\x3b\xb2\x3d\x5a\x2b\x58\x8d\x7d\x17\xa6\xc9\x3d\xa3\x00\xc4\x93\x13\xfe\xa9\x9b\x1d\x3f\xba\x13\x7e\xe4\x66\x13\x06\x9d\xc1\x4d\x91\x52\x68\xab\x32\xe9\x0b\x93\xfe\x62\xde\xe6\x7f\x07\x00\x90\x52\xe4\x1c\xa7\x51\x00\x00")
+
+func templatesClient_rpc_goTplBytes() ([]byte, error) {
+	return bindataRead(
+		_templatesClient_rpc_goTpl,
+		"templates/client_rpc_go.tpl",
+	)
+}
+
+func templatesClient_rpc_goTpl() (*asset, error) {
+	bytes, err := templatesClient_rpc_goTplBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "templates/client_rpc_go.tpl", size: 20903, mode: os.FileMode(420), modTime: time.Unix(1792417316, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _templatesHandler_nethttp_goTpl = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x9c\x58\x7b\x8f\xdb\xb8\x11\xff\x5b\xfa\x14\x73\x42\x1b\xc8\x0b\x85\xce\xa5\xe9\xfd\x91\x76\x0b\xe4\x36\x97\xe6\xae\xb7\x8f\x26\x5b\x5c\x81\x20\x30\x68\x69\x6c\xf1\x56\x22\x15\x92\xb2\xd7\x10\xfc\xdd\x8b\x21\x29\x59\xde\xf5\x7a\xdd\x0b\x90\xb5\x44\x91\xf3\xfc\xcd\x8b\xd3\x29\x5c\xa8\x02\x61\x89\x12\x35\xb7\x58\xc0\x7c\x03\x35\x9a\xf2\xd3\xcd\x05\x83\xf7\xd7\x70\x75\x7d\x0b\x3f\xbd\xff\xf9\x96\xc5\xd3\x29\xbc\xab\x2a\xc8\x4b\x2e\x97\x68\xa0\x6e\x8d\x85\x39\x42\xa1\x24\x82\x90\x90\xb7\xc6\xaa\x1a\xf2\x4a\xa0\xb4\x60\x4b\x6e\xc1\x94\xaa\xad\x0a\x40\x61\x4b\xd4\x80\xf5\x1c\x0b\x50\x1a\xd6\x9a\x37\x60\x4b\x61\x58\x1c\x37\x3c\xbf\xe3\x4b\x84\xae\x63\x37\xfe\xf1\x8a\xd7\xb8\xdd\xc6\xb1\xa8\x1b\xa5\x2d\xa4\x71\x94\xcc\x37\x16\x4d\x12\x47\x49\xae\xa4\xc5\x7b\x4b\x8f\x28\x73\x55\x08\xb9\x9c\xfe\x6e\x94\x74\x0b\x5a\x2b\xed\x76\x2d\x6a\xb7\x43\x28\xfa\x5b\xa9\x25\xfd\x48\xb4\xd3\xd2\xda\x86\x9e\x8d\xd5\xb9\x92\xab\xf0\x28\xe4\xd2\x9d\x32\x1b\x99\xf7\xbf\x53\x6e\x55\x2d\xdc\xab\x15\x35\x26\x71\x1c\x25\x4b\x61\xcb\x76\xce\x72\x55\x4f\xb9\xb1\x9a\x13\xc5\x60\xa8\x69\x81\x45\xdb\x24\xcf\x6c\x12\xd2\xa2\xce\xb1\xb1\xcf\x6d\xb4\x9a\xe7\xf8\xdc\xa6\x15\xaf\x44\xc1\x2d\x26\xf1\x24\x8e\xed\xa6\x71\x26\xfc\x80\xdc\xb6\x1a\x6f\x34\x2e\xc4\xfd\x76\xfb\xe9\xe6\xe2\x23\x97\x45\x85\x1a\x1c\xf3\x05\xcf\x11\xba\xb8\xeb\x5e\x82\x26\x27\x02\xbb\x44\x5b\xaa\xc2\x6c\xb7\x71\xd4\x75\xcc\x9b\x3e\x25\x3b\xb1\x4f\x68\x1a\x25\x0d\xfe\xa6\x85\x45\x9d\xc1\x59\x58\xfd\xd6\xa2\xb1\x13\x47\x03\x65\x41\x8e\x8a\xa6\x53\xf8\x20\xe4\x12\x75\xa3\x85\xb4\x97\xc2\xd4\xdc\xe6\x25\x1a\xd0\x68\x5b\x2d\x0d\xd8\x12\x41\xb6\xf5\x1c\x35\xa8\x05\xe4\xbc\xaa\x8c\x07\x48\xc9\x57\x08\x73\x44\x09\x06\xf5\x0a\x0b\x47\x4b\x48\x68\x50\xd7\xc2\x18\xb1\x42\xa8\x09\x9d\x0b\xa5\x03\xb0\x0c\xac\x85\x2d\x81\x43\x1d\xd8\x08\xb9\x84\xc5\x8e\x3b\x8b\xa3\x83\xb2\xa4\x13\x68\x85\xb4\x3f\xbc\x71\x2c\x7e\xa4\xc5\xde\x34\x63\x29\xcb\xb0\xa6\x16\xa0\xbd\xaa\x41\xd2\x9c\x6b\xbd\x01\x83\x2b\xd4\xbc\xf2\x2a\xb0\x38\x1a\xd3\x49\x27\xe0\x4c\x14\x5e\xe3\x6d\x1c\xaf\xb8\x3e\xea\x95\xcf\x0d\xe6\xc7\xdd\x76\x0e\x2f\xba\x8e\xed\x16\x6e\xb4\x58\x71\x1b\x62\xa4\xdb\xc6\x14\x94\xc7\x08\x8c\x6c\x01\xc2\x00\x87\x92\x9b\x92\xb4\x23\x65\x1f\x1f\xfc\x8c\x7a\x25\x72\x84\xb5\xd0\xce\xe8\x35\xb7\xc4\x81\x62\x75\x30\x4d\xc9\x8d\x77\xd9\x2e\x65\x2c\x94\x66\x70\x11\xfc\x63\x50\x16\x44\x5e\x68\x50\x6b\x39\xf6\x0d\xf0\x4a\xc9\x25\x20\xcf\x4b\x67\x41\x16\xe7\x4a\x1a\x7b\xb2\x02\xe7\x90\xd0\xd6\xdd\xca\x76\x9b\x9c\x80\xfd\xeb\xc6\x0a\x25\x0d\x18\xab\xdb\xdc\x42\xe7\x10\x70\x33\x20\xec\xc3\x9e\x80\x95\x5a\x1b\xb0\xca\xe3\x71\xc0\xdc\x03\xb0\xee\x69\xee\xc8\x71\xa9\x5c\x8e\x5b\xa1\x36\x42\xc9\xde\xc2\x26\xd8\x73\x88\xbe\x6c\xc0\x2d\x1a\xe0\x1a\xa1\x52\xcb\x25\x16\xc0\x65\x01\xb9\x6a\xa5\xc5\x82\x79\x84\x6e\xa0\xc0\x05\x6f\x2b\x0b\xa6\x0d\xf6\xf2\x27\x34\xfe\x8e\x39\xf1\x76\x81\xf0\xe6\xfb\xd7\x70\xa3\x31\x57\xb2\x10\xa4\x26\x7c\xe0\xa2\x72\x44\x0e\x6b\x38\x57\xaa\x72\x0c\x7e\xee\xb3\x91\xd2\xc6\x67\x64\xcf\xe2\x81\xe4\xb5\x4f\x10\x99\x5b\x5c\x08\x6d\x2c\xb8\x7c\xef\xc3\x45\xb5\x16\x75\xad\x8c\xf5\x42\x5f\xf4\x14\x38\xcc\x49\x47\x27\xef\x90\xf6\x28\xf9\x4b\xa4\xfa\xa2\x24\xb2\x38\xda\x93\xe0\xcb\xd7\x61\x1f\x1b\x7d\x70\x64\x2f\xf9\xbd\x0b\x34\x4f\xbe\x12\xb5\xb0\x87\x73\x8a\x90\x03\xe7\x10\xbd\x99\x23\xf0\x74\x08\xed\x93\x9e\x0f\x46\x67\x71\xb4\xff\x49\x48\xeb\xcd\x56\x60\xdd\x28\x8b\x32\xdf\xfc\x0b\x37\x06\xc4\x43\x49\x34\xe6\x48\xc1\xb6\xdb\x07\x77\xb8\x31\x59\x00\xbf\x73\x9a\x0e\xb9\xb5\xb7\xb6\xf0\x7a\x3a\x25\xb2\xbd\x3c\x74\x87\xd8\x38\x3c\x6a\x6c\x2a\xbe\xa1\x6f\xb5\x7f\xb5\x5a\xa0\xc9\x8e\xa8\xf6\x40\x52\xc7\x61\xa4\x20\xbc\x03\x89\x4b\x6e\xc5\x6a\x90\xbe\x10\x86\xcf\x2b\x34\x81\x19\x25\xb8\x47\xea\x3e\x36\xc3\xed\xed\xaf\xbd\x15\xa8\x56\x1e\x10\x9f\x93\x05\x28\x54\x4e\x14\xf7\xf6\xf6\xd7\x87\xd2\xc6\xd1\x03\x8e\xc4\x8a\xbd\x6f\x35\x27\xd0\xbb\x6a\x24\x16\xc0\x3e\x72\x73\x2d\x71\xcd\x37\x54\xd0\xa6\x53\xf0\x2f\xbf\x29\x7d\x87\xfa\x80\xaf\xd6\xe1\x83\x6f\x56\x7c\xc4\xf7\x10\x56\xee\xe8\x80\xff\x67\x70\xb4\xcf\x68\x4f\xf0\x07\x32\x04\x03\xfa\xd5\x7f\xb7\xd8\xe2\x63\xb9\x78\xbe\x8b\x17\x12\x62\x54\x31\x6b\xbe\x81\x35\x17\xd6\x95\x44\x1e\x34\x38\x4d\x3a\xcf\x6c\x0c\x81\x8b\x27\x92\xca\x5f\x5f\xfd\xc5\x51\xec\xcb\xc1\x7f\x24\x5f\x71\x51\x11\x38\x60\x5d\xa2\x74\xe2\x7e\xeb\x65\x5f\xb4\x55\x35\xe8\x19\x34\x92\x76\xd4\x21\x6c\xe3\x78\xd1\xca\x1c\xf2\x12\xf3\xbb\x13\x32\x75\xaa\x1a\x0b\x67\x27\x6c\x9c\x9c\xb4\x8b\x32\xbe\x58\x00\x11\x3d\x3f\x07\x29\x2a\x5a\x88\xdc\x2b\xbc\x38\xe1\x7c\xb7\x8d\xa3\x6d\x1c\xf9\x36\x81\xc8\x0c\x0a\x5d\xe1\xfa\xd8\xf9\x34\x8e\xcc\x2a\x7f\xba\xcc\x66\x71\x74\xaa\xa6\x59\x3c\x39\xde\x28\x74\x83\x80\xc7\x1a\x06\xaf\xf7\xdb\xff\xcb\x13\x93\x2c\x8e\x48\x8f\xb7\x60\x56\x79\x46\xa6\x78\xbe\xed\xb8\x6c\xef\x7d\xbb\xa1\x5d\x85\xd8\xcb\x09\x39\x97\x34\x36\xd4\xbe\xda\x81\x92\x19\x51\x43\xb6\x64\xa1\xc7\x24\xeb\x20\x51\xa0\xa6\xaf\x14\xec\x93\xa3\xc1\x9e\x2f\xf4\x8e\xeb\xa8\xcf\x8d\xfc\x7a\xda\x70\x6b\x51\x4b\xf0\xcd\x7e\xb6\xeb\x64\x46\xed\xda\x24\x68\x75\x49\x62\x1d\xb5\xb4\xc6\xa5\x30\x96\x62\x99\x57\xd5\x40\x2b\xe4\x09\x50\x3e\x38\xea\xf6\x1e\x5a\x23\xe4\xd2\xb7\x4f\x08\x86\xd7\x08\x0d\xb7\xa5\x01\xee\xc3\x3d\xaf\x5a\x22\x03\x85\xa2\x3c\x2e\x18\x32\x98\x3e\xed\xba\xe9\xdf\x7d\xab\xfe\x0f\x67\xad\x51\x2d\x21\x52\xbe\xe4\xf5\xa2\x70\x7b\x94\xd0\x6c\xe6\xb6\xcf\x66\xcc\x83\xf8\x59\x8d\x53\x52\xe6\x19\xbb\x67\x50\x1e\xdd\x32\x79\x72\xec\xa8\xdb\xfb\xe0\x83\x34\x99\x76\xdd\x9f\x9e\x94\x7b\x98\x4f\x92\x0c\x8e\xec\x7b\x47\x2d\x5c\x5a\xb2\x61\xfb\xd1\xdd\x41\x90\x4b\xde\x7c\x49\xce\x92\xaf\x8c\xb1\xc9\x78\xb6\x39\x5d\xba\xc1\xaa\xa7\x49\xb7\x3f\x38\x78\xc8\x7f\xbc\xbd\xbd\xc9\x20\xb9\xb9\xfe\x7c\x9b\x4c\x1c\x1e\x7b\xc4\x3f\x41\x6c\xbf\xa3\xa5\xa2\x61\x0c\x16\xe0\x67\x57\x5e\x55\x9b\x6c\x18\xd2\x7f\x78\xf3\x72\x2e\x2c\xf0\x4a\x2c\x25\x8d\x59\x8b\x83\xf3\x5a\x98\x90\x7c\xda\x7a\x3a\x6f\xf9\xb4\x75\x52\xde\x8a\xe3\xc8\xcd\xc6\xd7\x32\x47\xa0\xc9\x9a\xd1\x53\x58\x04\xfa\x77\xe6\x1e\xd9\x05\xcf\x4b\x3c\x58\xc7\xe3\xc8\x57\x41\x47\x62\x4c\xc3\x2f\xfb\x1a\x46\x97\x12\x40\x70\x4e\x27\xfb\x85\xa7\xeb\x1e\x41\xae\xeb\x2c\xd6\x4d\xc5\x2d\x42\x12\xda\xc4\x19\x4d\x99\x55\x02\xec\xd1\x67\xdf\xa8\x8d\xbf\xbb\xa0\x49\x67\x7d\xb4\x9d\x1d\xf1\xb6\xcb\xd9\xfe\x31\x9d\xad\xe1\xe0\x5c\x3d\xd3\x0f\x46\xeb\x50\xae\xbe\xeb\x39\x30\x97\xac\x47\x3d\x7c\x3a\x5b\xd3\xb1\x0c\x92\x81\x7a\xe2\x4e\x85\x0a\xe0\xaa\x15\x8d\x9e\x33\x8d\xdf\x76\x12\x04\xfa\x71\x34\x2b\x30\x57\x05\x6a\x78\x7b\x0e\x74\x79\xc2\xae\x70\xfd\xde\x2f\xa5\x33\xcd\x7e\x54\xc5\x66\x42\x1e\x5a\xa0\x86\xf0\xce\x2e\x2a\x65\x30\x9d\xc4\xd1\x0c\xb5\x3b\xd8\x13\x61\xfe\x64\xfa\x82\x98\x4d\x9c\xe4\x6e\xcb\x77\xbb\x4a\x7b\xc4\x40\xce\x08\xbf\x7c\xbe\xbe\x72\x3a\xbd\x79\xf5\x2a\xa3\x53\x99\x23\x31\x39\xa8\x90\x69\xc6\x1a\x79\x53\x06\xa9\xce\x61\x30\x99\x90\x2b\x75\x87\xa4\xcd\x85\xbf\x31\x4a\x27\x64\x32\xf6\x11\x79\x81\xfb\x96\xcb\xc0\x89\xee\x7f\x4c\x93\xc1\xd8\xff\x61\x0a\x9a\x79\x7a\xce\xff\x7f\x4c\xc7\x9f\xb4\x56\xda\x3b\xee\x91\x6a\xf1\xe9\x16\xea\x3a\x8a\x8e\x3e\x34\x5e\xbf\x7a\xdd\x75\x58\x19\xa4\xc7\x57\x5d\xe7\x40\xbf\x53\x44\x8a\x8a\xb2\x48\x58\x8f\x0f\x00\xf7\x69\xdc\x1e\xc0\xdc\x69\xf0\xcd\x42\x25\x0c\xc5\x76\xe2\x06\x4e\x32\xd1\x6c\x1d\xac\xef\xd2\x9d\x4d\x93\xff\xbe\xbc\xf4\x57\x59\x2f\x47\x7c\x5c\xf6\x3c\xe9\x36\xc0\xfb\xa1\x47\x63\xef\x78\x97\xc9\xf6\xe4\xee\x9d\xce\xfe\xf9\x34\xd3\x49\x2f\xf5\xe4\x6f\xf0\xc8\xb1\x27\x62\xf7\xfb\xd7\x01\xbb\x7b\xfe\x85\x05\xaf\x0c\x8e\xfb\x47\xab\x5b\xf4\x89\x69\x07\xb3\x11\xbc\xf6\x3f\x38\x75\x66\xa3\x64\x7d\x60\x8f\xaf\x3b\xc1\x00\x7d\x0a\x3b\x90\x46\xc7\x67\x7c\xea\x9c\x35\x4a\x55\xbb\x13\x01\x26\xe1\xd2\xea\xd9\x82\x09\xe7\x50\xf3\xe6\x8b\xf7\xf3\xd7\x2f\x5f\xfd\x43\x17\x47\xc9\x59\xf2\x16\x46\xef\x91\x2f\x69\xd4\x3a\x66\xf1\x0e\x87\xc7\x01\x48\xc5\x70\xc7\x2b\x9d\x1c\xe2\x35\x6a\x7a\x4f\x11\xf8\xa1\xd5\xbd\xcb\x67\x85\xca\xcd\x01\xb3\x52\x66\x9c\xad\x78\xd5\xf6\x7e\x39\x3a\x61\xb9\xba\x0e\x1a\x49\xb0\xdc\xee\xdf\x25\x3a\x7d\xad\x72\x6b\x95\x30\x16\x0b\xa7\x5d\xdf\x32\x86\x36\xec\x19\xda\xe9\x22\xd4\xb7\x13\x2e\x67\x7b\x34\x1b\x60\x8c\xf5\x71\x38\x6e\x76\x47\x86\x1b\x2f\x7f\x20\xfa\x8e\xc9\x13\xc1\x7e\xa8\x54\x45\x34\x88\xce\x32\xa8\x29\x0e\x7d\xad\xed\xb9\xd3\x57\x0a\x52\x1d\x8a\x2f\x8d\x5f\xb5\x5f\x8d\x16\x32\x5d\x67\xe0\x22\x65\x97\x0b\x23\x8a\x13\xf7\xff\x61\xb2\x70\x36\x48\xb2\x90\x55\x0c\xfb\x45\x09\x99\x0e\x77\x53\x49\x06\xd4\x31\x9d\x16\xad\xeb\xcc\x2b\xf7\xd9\x72\xdb\x1a\x2f\xd9\x95\xb2\x8e\x03\x16\x3e\x88\x49\x94\x45\x6d\x99\x4b\xdb\x8b\x34\x40\x05\xfe\xec\xee\x10\xa4\x0a\x17\x85\x58\x24\xd9\xa0\x1c\xf1\xdf\x52\xbe\xfd\xdf\x00\x5d\x10\x28\x8e\x52\x19\x00\x00")
+
+func templatesHandler_nethttp_goTplBytes() ([]byte, error) {
+	return bindataRead(
+		_templatesHandler_nethttp_goTpl,
+		"templates/handler_nethttp_go.tpl",
+	)
+}
+
+func templatesHandler_nethttp_goTpl() (*asset, error) {
+	bytes, err := templatesHandler_nethttp_goTplBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "templates/handler_nethttp_go.tpl", size: 6482, mode: os.FileMode(420), modTime: time.Unix(1792415216, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _templatesHandler_rpc_goTpl = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x94\x57\x5b\x6f\xdb\xc8\x15\x7e\xe6\xfc\x8a\x53\xa2\x08\x44\x83\xa1\xbc\x69\xba\x0f\x59\xf8\x21\xeb\x6c\x9a\x6d\xd7\x97\xc6\x2e\xb6\x40\x10\x08\xa3\xe1\x91\x38\x31\x39\xc3\xcc\x0c\xa5\x08\x84\xfe\x7b\x31\x17\x52\x94\x4c\xc9\xaa\x1f\x2c\x72\x2e\xe7\xf2\x9d\xef\x5c\x38\x9d\xc2\xb5\xcc\x11\x96\x28\x50\x51\x83\x39\xcc\x37\x50\xa1\x2e\x3e\xdf\x5f\x67\xf0\xe1\x0e\x6e\xef\x1e\xe1\xb7\x0f\xbf\x3f\x66\x64\x3a\x85\xf7\x65\x09\xac\xa0\x62\x89\x1a\xaa\x46\x1b\x98\x23\xe4\x52\x20\x70\x01\xac\xd1\x46\x56\xc0\x4a\x8e\xc2\x80\x29\xa8\x01\x5d\xc8\xa6\xcc\x01\xb9\x29\x50\x01\x56\x73\xcc\x41\x2a\x58\x2b\x5a\x83\x29\xb8\xce\x08\xa9\x29\x7b\xa2\x4b\x84\xb6\xcd\xee\xfd\xe3\x2d\xad\x70\xbb\x25\x84\x57\xb5\x54\x06\x26\x24\x8a\xe7\x1b\x83\x3a\x26\x51\xcc\xa4\x30\xf8\xc3\xd8\x47\x14\x4c\xe6\x5c\x2c\xa7\xdf\xb4\x14\x6e\x41\x29\xa9\xdc\xa9\x45\xe5\x4e\x70\x69\xff\x97\x72\x69\x7f\x04\x9a\x69\x61\x4c\x6d\x9f\xb5\x51\x4c\x8a\x95\x7b\xdc\x08\xd6\xfd\x4e\xa9\x91\x15\x77\xaf\x86\x57\x18\x13\x12\xc5\x4b\x6e\x8a\x66\x9e\x31\x59\x4d\xa9\x36\x8a\x76\x62\x34\xaa\x15\xc6\x47\x0e\x04\xf8\xa6\x39\xe6\x4d\xfd\xd2\x21\x2e\x0c\x2a\x86\xb5\x79\xe9\xa0\x51\x94\xbd\xa8\x72\x45\x4b\x9e\x53\x83\x31\x49\x08\x31\x9b\xda\x01\xfb\x11\xa9\x69\x14\xde\x2b\x5c\xf0\x1f\xdb\xed\xe7\xfb\xeb\x4f\x54\xe4\x25\x2a\x70\xca\x17\x94\x21\xb4\xa4\x6d\x5f\x83\xb2\xa1\x85\xec\x06\x4d\x21\x73\xbd\xdd\x92\xa8\x6d\x33\x1f\x90\xc9\x45\xef\x77\x76\xed\xc3\x90\xc0\x6e\xe9\x33\xea\x5a\x0a\x8d\x4e\x0c\x8a\xdc\x46\x30\x9a\x4e\xe1\x23\x17\x4b\x54\xb5\xe2\xc2\xdc\x70\x5d\x51\xc3\x0a\xd4\xa0\xd0\x34\x4a\x68\x30\x05\x82\x68\xaa\x39\x2a\x90\x0b\x60\xb4\x2c\xb5\x67\x4e\x41\x57\x08\x73\x44\x01\x4e\x7c\xee\x64\x71\x01\x35\xaa\x8a\x6b\xcd\x57\x08\x95\xa5\xed\x42\xaa\xc0\x38\x0d\x6b\x6e\x0a\xa0\x50\x05\x35\x5c\x2c\x61\xb1\xd3\x9e\x91\x68\xd4\x96\x49\x02\x0d\x17\xe6\xe7\xb7\x4e\xc5\xaf\x76\xb1\x43\x67\x68\x65\x11\xd6\xe4\x02\x14\x7e\x6f\x50\x9b\x60\x29\xa3\x4a\x6d\x40\xe3\x0a\x15\x2d\xbd\x0b\x19\x89\x86\x72\x26\x1e\xa7\x2c\xbc\x92\x2d\x21\x2b\xaa\x4e\x06\xe6\xa1\x46\x76\x3a\x72\x57\xf0\xaa\x6d\xb3\xdd\xc2\xbd\xe2\x2b\x6a\x42\xf2\xb4\x5b\x62\xb3\xf5\x94\x80\x01\x16\xc0\x35\x50\x28\xa8\x2e\xac\x77\xd6\xd9\xe7\x17\x1f\x50\xad\x38\x43\x58\x73\xe5\x40\xaf\xa8\xb1\x1a\x6c\x12\xf7\xd0\x14\x54\xfb\x90\xed\x6a\xc9\x42\xaa\x0c\xae\x43\x7c\x34\x8a\xdc\x8a\xe7\x0a\xe4\x5a\x0c\x63\x03\xb4\x94\x62\x09\x48\x59\xe1\x10\xcc\x08\x93\x42\x9b\xb3\x1d\xb8\x82\xd8\x1e\xdd\xad\x6c\xb7\xf1\x19\xf4\xbf\xab\x0d\x97\x42\x83\x36\xaa\x61\x06\x5a\xc7\x80\xfb\x9e\x61\x1f\xf7\x0c\x2c\xe5\x5a\x83\x91\x9e\x8f\x3d\xe7\x0e\xc8\xba\xe7\xb9\x13\x47\x85\x74\xc5\x6f\x85\x4a\x73\x29\x3a\x84\x75\xc0\xb3\x4f\xc0\xb4\xe7\x2d\x6a\xa0\x0a\xa1\x94\xcb\x25\xe6\x40\x45\x0e\x4c\x36\xc2\x60\x9e\x79\x86\x6e\x20\xc7\x05\x6d\x4a\x03\xba\x09\x78\xf9\x1b\x0a\xbf\x21\xb3\xba\x5d\x22\xbc\xfd\xe9\x0d\xdc\x2b\x64\x52\xe4\xdc\xba\x09\x1f\x29\x2f\x9d\x90\x71\x0f\xe7\x52\x96\x4e\xc1\xef\x5d\x41\x92\x4a\xfb\x52\xed\x55\x1c\x58\x5e\xf9\x1a\x91\xba\xc5\x05\x57\xda\x80\x6b\x04\x3e\x5d\x64\x63\x50\x55\x52\x1b\x6f\xf4\x75\x27\x81\xc2\xdc\xfa\xe8\xec\xed\x2b\x9f\xed\x0a\x02\x6d\xe3\x91\x02\x33\x12\xed\x59\xf0\xe5\x6b\x7f\x2e\x1b\x6c\x38\xb1\x37\xf4\x87\x4b\x34\x2f\xbe\xe4\x15\x37\xe3\x35\x85\x8b\x5e\x73\xc8\xde\xd4\x09\x38\x9e\x42\xfb\xa2\xe7\x3d\xe8\x19\x89\xf6\xb7\xb8\x30\x1e\xb6\x1c\xab\x5a\x1a\x14\x6c\xf3\x2f\xdc\x68\xe0\x87\x96\x28\x64\x68\x93\x6d\x77\x0e\x9e\x70\xa3\xd3\x40\x7e\x17\x34\x15\xaa\x68\x87\x36\xf7\x7e\x3a\x27\xd2\xbd\x3a\xf4\x84\x58\x3b\x3e\x2a\xac\x4b\xba\xb1\x7b\x95\x7f\x35\x8a\xa3\x4e\x4f\xb8\x76\x60\xa9\xd3\x30\x70\x10\xde\x83\xc0\x25\x35\x7c\xd5\x5b\x9f\x73\x4d\xe7\x25\xea\xa0\xcc\x16\xb8\x67\xee\x3e\x87\xe1\xf1\xf1\x8f\x0e\x05\xdb\x4f\x47\xcc\xa7\x16\x01\x9b\x2a\x67\x9a\xfb\xf8\xf8\xc7\xa1\xb5\x24\x3a\xd0\x68\x55\x65\x1f\x1a\x45\x2d\xe9\x5d\x37\xe2\x0b\xc8\x3e\x51\x7d\x27\x70\x4d\x37\xb6\xa7\x4d\xa7\xe0\x5f\xfe\x94\xea\x09\xd5\x48\xac\xd6\x61\xc3\x4f\x31\x3e\xe3\x3b\x0a\x4b\x77\xb5\xe7\xff\x0b\x3c\xda\x57\xb4\x67\xf8\x81\x0d\x01\x40\xbf\xfa\xef\x06\x1b\x7c\x6e\x17\x65\xbb\x7c\xb1\x46\x0c\x3a\x66\x45\x37\xb0\xa6\xdc\xb8\x96\x48\x83\x07\xe7\x59\xe7\x95\x0d\x29\x70\x7d\xa4\xa8\xfc\xfd\xf2\x6f\x4e\x62\xd7\x0e\xfe\x23\xe8\x8a\xf2\xd2\x92\x03\xd6\x05\x0a\x67\xee\xf7\xce\xf6\x45\x53\x96\xbd\x9f\xc1\x23\x61\x06\x13\xc2\x96\x90\x45\x23\x18\xb0\x02\xd9\xd3\x19\x95\x7a\x22\x6b\x03\x17\x67\x1c\x4c\xce\x3a\x65\x2b\x3e\x5f\x80\x15\x7a\x75\x05\x82\x97\x76\x21\x72\xaf\xf0\xea\x8c\xfb\xed\x96\x44\x5b\x12\xf9\x31\xc1\x8a\xe9\x1d\xba\xc5\xf5\xa9\xfb\x13\x12\xe9\x15\x3b\xde\x66\x53\x12\x9d\xeb\x69\x4a\x92\xd3\x83\x42\xdb\x1b\x78\x6a\x60\xf0\x7e\xbf\xfb\xbf\x22\x91\xa4\x24\xb2\x7e\xbc\x03\xbd\x62\xa9\x85\x62\xbb\x6b\xba\x47\x14\xed\xf7\x5a\x4b\x67\xad\x31\x07\x3f\x79\xd3\xb2\xdc\xa4\xfd\x77\xc5\xcf\x6f\x5f\xcf\xb9\xed\xbc\x7c\x29\xec\x00\xb8\x18\x9d\x24\xc3\xec\xe6\x01\x3d\x8e\xa8\x07\xf4\x2c\x44\x09\x89\xdc\xe0\x7e\x27\x18\x82\xfd\x2e\xc8\xec\x53\x58\x04\xfb\x77\xe1\x1e\xb3\x6b\xca\x0a\x1c\xad\x30\x24\xf2\xf9\xe9\x44\x0c\x65\xf8\x65\x9f\x5d\xf6\x3b\x0a\x2c\x5b\x26\xc9\x7e\x4a\xb4\xed\xb3\x31\xbc\x6d\x0d\x56\x75\x49\x0d\x42\x1c\x1a\xd8\xcc\xce\xbf\x65\x0c\xd9\xb3\x6d\xdf\x42\x86\xfb\x8e\x93\x93\x59\x57\x7c\x2f\xda\xf6\xaf\xc7\x02\xe4\xd8\xe4\x1f\x27\x33\x66\x7e\xc0\xd8\xd8\x3f\x99\x29\xd4\x23\xc3\x7f\x12\x32\xca\x6d\x5f\x41\xa7\x30\x73\xac\x1a\x0c\x1b\x4e\x72\x0a\x71\xaf\x2a\x4e\x7e\xf1\x97\xfe\xb2\xcb\x43\xcf\x5a\x97\x61\x76\x5c\x9e\x29\xfc\xbe\xb3\xed\xb3\x07\x81\x44\xb3\x1c\x99\xcc\x51\xc1\xbb\x2b\xb0\x5f\x82\xd9\x2d\xae\x3f\xf8\x25\xa7\x25\x0b\x27\xb3\x5f\x65\xbe\x49\x6c\x14\x17\xa8\xe0\xd9\x4e\x76\x5d\x4a\x8d\x93\x84\x44\x33\x54\x4e\x58\x27\x38\xf3\xd2\x26\xaf\xac\x01\x89\x77\xcf\x1e\x19\x58\x1a\xdc\xdd\xe1\x71\x8b\xeb\x7f\x3e\xdc\xdd\x76\xb0\x4c\xde\x5e\x5e\xa6\xee\x56\x32\xea\x97\xae\x87\x8e\xf9\x3b\xc1\x90\x01\x88\x5c\xac\xe4\x13\xee\x3b\x15\x22\x32\x49\xd2\x7d\x97\x3e\x21\xcd\x51\xed\x21\x9c\x82\xf3\xc0\xff\xe8\x3a\x85\x21\x69\xc2\x50\x37\xf3\x3a\x1c\x69\xc6\x5d\x3d\xc1\x9c\x3f\x15\x37\xf8\x9b\x52\x32\x00\xef\xde\xd5\xce\xef\x31\x94\xde\xe7\xb2\x36\x3d\x4c\xfb\xe8\x90\x97\x71\x6d\x5b\x9b\x79\x5d\xda\xbd\xb9\x7c\xd3\xb6\x58\x6a\xb4\x8f\x97\x6d\xeb\x12\xaa\xf3\x37\xe9\xca\x20\xd9\x92\xb0\x45\x46\xf2\xe2\x78\x5a\x8c\x72\x78\x24\x3b\xd2\x30\x19\xd8\x4a\xc7\xc5\x72\xec\x1b\xd9\x22\x39\x80\x28\x44\x6b\x92\x64\x0f\x68\x26\xf1\x7f\x5f\xdf\xf8\x8f\xf9\xd7\x03\x6d\x71\x7a\xee\xc7\x90\x8f\x5b\x47\xe2\x8e\x3c\xae\x5c\x1e\x5a\x7f\x40\x97\xec\x1f\xc7\xd5\x27\x9d\x5b\xc9\x2f\x70\x40\x89\xd0\x5d\x4e\xb0\xff\xa7\x37\x29\x78\x12\xec\xba\xa5\xe0\xa5\xaf\x75\x3b\x12\x0e\xc8\xb7\xbf\xe1\x8c\x9f\x0d\xea\xff\xc8\x19\x37\xdc\x77\xee\x76\x55\x71\xa4\x32\x0f\xef\xf8\x6a\x3c\xab\xa5\x2c\x77\x37\x02\x35\xc2\x17\xfa\xb1\xaf\x03\x5f\x99\x6f\x68\x0d\x57\x50\xd1\xfa\x8b\x0f\xf6\xd7\x2f\x5f\xfd\x43\x4b\xa2\xf8\x22\x7e\x07\x83\xf7\x28\xbe\xbf\x7b\x78\x8c\x6d\x9f\x4c\xc9\x8e\x7b\xa7\x49\xf7\xe9\xf1\xf1\x7e\xa7\x6b\x92\x8c\xe9\x1a\x74\xf8\x73\x0c\x3e\x44\xdd\x87\x75\x96\x4b\xa6\x47\x60\xb5\x25\x75\xb6\xa2\x65\x13\xe2\xf2\xbf\x01\x00\x97\x93\xfc\xa2\x2f\x14\x00\x00")
+
+func templatesHandler_rpc_goTplBytes() ([]byte, error) {
+	return bindataRead(
+		_templatesHandler_rpc_goTpl,
+		"templates/handler_rpc_go.tpl",
+	)
+}
+
+func templatesHandler_rpc_goTpl() (*asset, error) {
+	bytes, err := templatesHandler_rpc_goTplBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "templates/handler_rpc_go.tpl", size: 5167, mode: os.FileMode(420), modTime: time.Unix(1792415216, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _templatesModels_goTpl = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xbc\x5a\x5f\x93\xdb\x46\x8e\x7f\xa6\x3e\x05\x4e\x15\x67\x29\x9b\xe6\x8c\x7d\xd9\x7d\x90\xa3\xad\xda\xd8\xf1\xc5\x97\x38\xf1\x79\x9c\xdd\xab\x72\xb9\xb8\x3d\x64\x73\xd4\x3b\x14\x5b\xee\x6e\xcd\x58\xab\xd5\x77\xbf\x02\xd0\xcd\x7f\x92\x66\x38\xa9\xdc\xe6\x21\x1e\x91\x4d\x00\x8d\x06\xf0\x03\xd0\xd8\xed\xce\x1e\xc3\x3b\x61\x9c\x12\x95\x05\xbb\x14\x46\x16\x70\xb9\x85\xa5\xa8\x8b\x4a\x1a\x10\x75\x01\x79\xa5\x64\xed\xc0\xc9\xd5\xba\x12\x4e\xda\x04\xdc\x52\x42\xa1\x1d\x28\x0b\x02\x1e\xbf\x95\x6e\xa9\x0b\xd8\xd4\x95\xb4\x16\x6a\xed\x64\x91\xc2\xe3\xb3\xfd\x7e\x32\xd9\xed\x0a\x59\xaa\x5a\xc2\xd4\xc8\xcf\x1b\x69\x5d\xb6\xd2\x85\xac\xa6\xfb\xfd\xe4\xec\x0c\x76\xbb\xf4\x67\xb1\x92\xfb\xfd\x7b\x7e\x09\x4b\x5d\x15\x16\xd6\xc2\x88\x95\x05\x5d\xb6\x0b\xd2\xc9\x6e\xf7\x14\x6e\x95\x5b\x42\xfa\x4a\xe7\xf4\xf9\x64\xb7\xcb\xf5\x6a\x85\xa2\xa5\xfb\x3d\x2d\x90\x75\xb1\xdf\x4f\xdc\x76\x2d\x0f\x69\x5b\x67\x36\xb9\x83\x1d\x2d\x34\xa2\xbe\x92\x90\xbe\x23\x4e\xfb\xfd\x6e\xa7\x4a\xf0\xeb\x0f\x38\x45\xa7\xf8\x44\xbb\x5d\xfa\x5a\xc9\xaa\xe0\x0f\x91\xe5\x87\xed\x1a\xff\xfa\xfb\x3f\xac\xae\xe7\xd3\xdd\x2e\xfd\xef\x8b\x5f\x7e\xfe\x51\x6e\xf7\xfb\x44\xaf\x14\x6a\xd0\x6d\xa7\xbb\x1d\x93\xff\xab\xa8\x54\x21\x1c\x7e\x70\xe3\xff\xa4\x6f\xf6\xfb\xe9\x6e\x47\x2c\xfe\xde\x72\xf3\x4f\x26\xfb\x49\xf8\xab\xa7\x5c\xbb\xd6\xb5\x95\xa7\xb4\xcb\x6f\xbd\x7a\x8d\xb4\x9b\xca\xfd\x8e\xfa\xf5\xd4\x8f\x29\xf8\xbd\x0c\xda\xad\xb5\x83\xf4\x8d\xfd\xde\x18\x6d\xfe\x1d\x4a\x1e\xab\xba\x15\x19\x6f\x56\xe8\xdc\x4e\x3d\xe3\xb3\xc7\x03\x03\xff\xe0\x2d\xff\xa5\xae\x9d\xfc\xe2\xe0\xf1\x19\x3c\xdd\xef\x27\x37\xc2\xa0\x38\xef\xdf\xbd\xfc\x81\xbd\xe5\x9d\x51\x37\xc2\x49\x96\x94\xbd\xe2\x95\xce\x2d\x2c\x60\x25\xd6\x1f\xad\x33\xaa\xbe\xfa\xc4\xff\xf4\xb4\xc4\x4b\x1b\x3b\xf4\x1a\x99\x36\x2a\x9e\xce\x61\xb7\x5b\x1b\x55\xbb\x12\xa6\x8f\x3e\x4f\xfd\x92\xe4\xd8\x16\xf1\xe4\x3b\xac\x8d\x74\x1b\x53\x5b\x28\x74\x0e\x5e\xc1\x74\xf2\xb8\x41\x2b\xcd\x8d\xca\x25\xa8\xda\x49\x53\x8a\x5c\x02\x2b\xc3\x26\x18\x01\xf8\x6f\xa8\xc5\x4a\xa6\x93\x72\x53\xe7\x10\x67\xf0\xf8\xf4\x7e\x67\x1d\xb6\xf1\xec\x70\xc7\xb0\x9b\x44\x2c\xcd\x28\xa5\x4d\xba\x46\xd0\x39\x2f\x2f\x74\x96\x8b\x8a\x0c\x3d\xc2\x55\xaa\x6c\x4c\x8d\x55\xfa\x95\x4a\xe0\x2b\x03\xf3\x45\xd7\x04\xbf\x52\xfb\x7d\x02\x5e\x55\xac\xea\xc6\x20\x33\x69\xcc\x6e\x27\x2b\x2b\xf7\xfb\x0c\xdd\x29\xed\xdb\x5d\xf3\x15\xfd\x03\x0b\x26\x43\x66\x10\x65\x3e\x58\xa6\xf6\x26\x4f\x9b\x43\x8b\x99\x83\xb7\x99\xfd\x3e\x77\x5f\xf8\x49\x08\x3a\x09\xf4\x89\xf6\x64\x5f\x93\xec\xbd\xf8\x74\x44\x7c\xe6\x94\x19\xf9\xf9\x50\x5c\xda\x4a\xad\xaa\x3e\x93\xd9\xdd\x5a\x55\xf5\x8d\xbe\x96\x03\x47\xa0\xc3\x77\x4b\xe1\x9a\x30\x65\xe9\xb9\x0f\xe9\x8c\x11\xa2\xaa\x6c\xd7\xaa\x12\xb0\x52\x02\xd3\x0b\x0e\x83\x84\xe2\xdc\x7d\x81\x9c\x95\x12\x94\x33\x83\x18\xf5\x0f\x12\xcf\x62\x86\x86\x12\xa9\x12\xe8\xd1\xa2\xe1\x99\x5e\x50\x7c\x89\xbf\xc6\xed\xce\x5e\xf0\xeb\xff\x58\x40\xad\x2a\xfa\xc2\x1b\xd7\x24\x8a\xf6\x93\xa8\xb1\x8a\x5f\x6a\x79\x2b\xb6\x78\x4a\xfe\x3d\x34\xa7\x75\xa5\xf9\x25\x4a\x94\x40\xc7\xdb\x12\x38\x2d\x28\x71\xda\xed\x02\x16\x0e\xec\x91\x62\x57\x14\xed\x67\x5e\x02\x3e\x84\xc9\xfd\x5f\x34\xb2\x77\x02\xdf\x09\x07\x38\x7a\x44\xf7\xc6\xaa\xb3\xb3\x70\x16\xd6\x09\xe3\x70\xa9\x5d\x8b\x3a\x84\x02\x94\x65\x18\x16\x38\x00\x24\xa4\x03\x55\x6f\xd0\x85\xf1\xb5\x33\x22\x97\x7e\x2d\x92\xc5\x4f\xa5\x49\xc8\x0a\xd6\xc2\x5a\x6f\x1c\x44\xd1\x2d\x8d\xde\x5c\x2d\x39\xc2\xe4\x72\xed\xb4\x69\xa2\x4f\xc8\x30\xf4\xda\x29\x5d\x73\x52\x51\xc3\x4a\x5c\x4b\x0b\xca\x21\x65\xc2\x87\xb2\x4e\xe1\x03\xd2\xf3\xfb\xe9\x0a\x2c\xbf\xac\x95\x91\x16\x44\xa5\xeb\x2b\x5e\x4e\xca\x90\xa2\xa8\x50\x57\x61\x2d\xe5\x2f\x2c\xa1\xb2\x2c\x73\x9d\xcb\xaa\x92\x05\xe8\x3a\x97\x9d\x45\x70\xa5\x91\xde\xad\xd8\x7a\x79\x7a\x71\xd2\xd6\x7f\x70\xbc\xdf\x02\x84\x03\x14\x41\x95\xa0\xdc\x1f\x28\xeb\x81\xad\x74\x29\x52\x7f\x49\xbe\x40\xe2\x88\x1a\x54\x21\x57\x6b\xed\x64\x9d\x6f\xe1\x5a\x6e\x41\x18\x09\x2b\x51\x48\x62\xcd\x5e\x82\x24\x7f\xa9\xf3\x36\xce\x06\xdd\xdc\x19\x6e\xf9\x3c\x8f\x99\x69\x02\x4b\x29\x0a\x69\x60\xe9\xdc\x3a\xfd\x81\xfe\x4e\x26\x91\x8f\xe8\x1c\x8e\x13\x74\x5f\xfc\x9f\x5d\xb7\x00\xb0\xdb\x27\x50\xd6\x77\x98\xbf\xf7\xd0\xb8\xef\xad\xe4\x40\xac\x53\x0c\x5b\xa7\x85\xfe\x9b\x72\xcb\x0f\x6a\x25\xf5\xc6\xb1\xd7\xb1\x9c\xb3\x49\x54\xc8\x52\x1a\x4f\x23\x9e\x79\x92\x64\xa1\xf3\x05\x9b\x5c\x7a\x81\x86\xdb\x3a\xeb\x05\x9f\x0a\x13\x3e\x9b\x3e\x09\xe6\xca\x8b\x7f\x54\x75\x81\x2b\xa4\x39\x60\x42\xbb\x6b\xa2\xcc\x30\x8a\x58\x27\xdc\xc6\x26\x90\xdd\xbd\x13\x82\x8d\x0b\x5a\x8b\xca\x98\xd1\xa7\x6b\x51\xa7\x17\xd2\xf9\xc7\x4c\x69\xe6\x03\x12\xbd\x7c\xad\x6a\x65\x97\xfe\x83\x3d\xee\x53\x95\x50\xc9\xba\x39\xf1\x54\xaf\x5d\xfa\xa6\xe3\x2d\x33\x58\x2c\xe0\x1c\x76\x4d\x8c\x68\xe3\x57\xb0\x9a\xae\x26\x93\xc6\x6b\x9b\xd3\xc5\x13\x45\x6e\x93\x08\x3f\xc0\x5d\x7d\xdd\xb8\x63\x8a\xa6\x8a\xb4\xbd\x32\xe7\x70\xa8\xda\x69\x32\x89\x22\xc6\xe5\x39\x40\xa0\x3f\x89\x22\x9f\x58\xcf\x81\x78\xd1\x03\xce\x04\xe7\xcc\x77\x12\x45\x6c\x7a\xf8\xd9\x32\x18\x61\xc4\xa7\x82\xcf\x9c\xd9\xc8\x84\x24\xf3\x5b\x6b\xe5\x7a\xd3\xd8\x76\x02\x27\x75\x93\x90\xe3\x9c\x8e\xd6\x78\x88\x8f\xfb\x7b\xf5\x16\xfc\x7b\xe8\x73\xe6\x93\xad\xd3\x36\x82\x0c\xff\x52\xa9\x1b\xe9\xd1\xb1\xac\x43\x8d\x84\xc2\x2a\x4c\xca\x6a\x99\x80\x4c\xaf\xd2\x6e\x08\x5a\x0a\x0b\x57\xba\x96\x14\x86\x7c\x48\x18\xc1\xe4\xb8\x06\xc6\x78\x73\xa3\x12\xef\x0e\xf3\x05\x0a\x98\x7e\x6f\x4c\x3c\x7b\x31\x74\x10\xaf\x35\x69\x4c\xf7\xe4\xca\x1a\x39\x90\x4a\x72\x5d\x5b\x07\xf1\x24\xba\x53\x37\x6f\xda\xa8\xf8\xa3\xdc\x5a\xd4\x06\x87\xee\x52\x6c\x2a\x07\xf5\x66\x75\x29\x0d\x06\xf0\x41\xf8\x44\xe0\x0a\xd1\xf1\x5a\xca\xb5\x4d\x27\xd1\x78\x2e\x0b\x78\x76\xfe\xfc\x9b\xf1\xa2\x7d\xf8\xf0\xd3\x50\x32\xa7\x56\x72\x28\xc3\xb1\x30\x5f\x6a\x33\x56\x34\xe4\x82\x92\xc1\x63\xa2\x9e\xbe\x55\xf5\xc6\xc9\xc9\x6c\x12\xe0\x15\xad\xd2\x23\x63\x83\x7d\x1e\x1c\x93\x60\x52\x0a\x91\xc9\x18\xe5\xd7\x0c\xc5\xd1\x25\x08\xfe\xd0\x69\x5a\xc0\x76\x8d\x1c\x28\xb3\x43\xa3\xbb\x94\x04\xc0\x85\x04\x51\x19\x29\x8a\x2e\x06\x86\x0a\x93\x31\x55\x30\x0c\xa2\x6e\x8c\x5c\x57\x62\x2b\x0b\x74\x5e\x4d\xcb\x3c\x5e\x8b\x7c\x89\xab\x91\xc3\xb5\x3f\xe1\xdc\x48\xe1\x64\x31\xc4\xeb\x52\x19\xeb\x42\xc2\x20\xda\x7d\xe8\x5a\xa6\x1e\x4c\x85\xc1\x13\x28\x36\xeb\x4a\xe5\x44\x42\xd5\x56\x15\x92\xd3\x9a\x6e\x44\xb0\x9a\x69\xb0\x58\xfc\xa1\xd8\xb8\xa5\x36\xea\x9f\xb2\x60\x38\x17\x2b\x09\xb7\x62\x0b\xc2\xb2\x63\x3e\x0c\x76\xbb\x61\xe2\xdf\x0e\xbc\x8d\xab\xe2\x99\xce\x17\x9e\x5d\xfa\x5f\xd2\xc5\xa4\x9e\xf4\x47\xb9\xfd\x21\xc0\x9d\xc7\x96\x6b\xb9\xf5\x28\xf2\xaf\x7f\x0d\x82\xe9\xc0\x3d\xbe\xed\x23\xcd\xc8\xa0\xd3\x02\x4c\x43\x9c\x64\xa1\x8c\xe6\x95\x8e\x3b\x78\x6b\xd5\x3f\x65\x02\xce\x11\x0c\xdd\x25\x4a\x72\xf2\xed\x87\x0f\x3f\x31\x70\x23\xad\x16\x1c\x23\xfe\x39\x3e\xe2\x78\x58\x56\x25\x89\xf3\x6d\x43\x07\x7f\x2d\x46\x47\x07\x4f\xa5\xbf\x71\x58\xb0\xad\xa6\x3f\xcb\xdb\x97\xe8\x06\x71\xb3\x6f\x06\x8e\x03\xe8\xe1\xe5\xaf\x34\xab\x93\xcd\xe5\xc9\xf4\x6c\xfa\xe4\x5a\x6e\xfb\xd0\xc3\xba\x3c\x00\xb1\x07\x1e\xd5\xfd\xd8\xd5\xc9\xd4\xa0\x90\x46\xdd\x48\x7b\x3c\xb7\x46\xaf\x2d\x8d\x5e\xd1\xaf\xff\x7d\xfa\x56\xda\xe5\xfb\x77\x2f\x9f\xfa\x6f\x91\x49\x40\x52\xf2\x4b\x1f\x4d\x29\x8a\x56\xb2\x74\xb0\xa9\x9d\xaa\xee\x4c\xdb\x09\x1f\xa7\xcf\xd2\x3f\xda\xe9\xbd\x68\x38\x48\x30\xc7\xf8\xe7\x0c\xe2\x83\x55\xcd\x03\xca\x47\x5f\x6f\xea\x9c\xec\xd7\x31\xe5\x24\xa0\x24\xfe\xc6\x1a\xdd\xca\x57\x1b\x23\xb0\x8c\x89\x3b\x0e\x39\x3d\xd0\xc6\x74\x36\x9b\x1c\xc9\x39\xfd\x19\xf2\xf9\x78\x67\xd9\x77\xd1\x35\x88\x73\x90\x3e\x7b\x81\x66\xa7\x9a\x25\x2b\xe1\xf2\x65\x56\xaa\xfa\x4a\x1a\xea\x1f\xfd\x86\xb2\x91\x68\xbc\x6e\x49\x34\x2d\x25\x51\x7b\x3b\x54\xa5\x0f\xe3\xed\x9a\xde\x11\x42\xa1\x25\x95\x4e\x44\x8a\x21\x47\x62\x74\x1f\x94\x82\x0d\x96\xad\x94\xa5\xa5\x92\xe3\xf7\x5a\x9a\x95\x72\x4e\x16\x0f\x0b\xd4\x43\xc1\xe3\x8e\x80\x09\xf4\x62\x72\x3f\x07\xc2\xa0\xd9\x59\xdb\x06\xcf\xee\x0e\x17\x14\x24\x5e\x4b\xe1\x36\x46\xbe\x33\xb2\x54\x5f\xf6\xfb\x56\x96\xae\xc2\x3a\x67\x5c\xab\x8a\x0e\x16\xdb\x1b\xdd\x00\xf7\x0e\xb7\x68\xad\xba\x91\xc3\x0f\x85\xd3\x2b\x95\xa7\x7f\x29\x8a\x5f\x55\xed\xfe\xf4\x4d\xfc\x75\xf3\x61\x47\x9c\xb7\x8d\xc6\x12\x78\x86\x25\x47\xa5\xaf\xd2\x77\xf8\xa6\x8c\xa7\xa7\x95\x34\x87\x47\x36\x14\xb4\x97\x5b\x10\xe1\xc0\x38\xb9\xe8\x48\xf2\xc8\x26\x58\x6d\xcb\xdc\xc9\x02\x1e\x59\xaa\x09\xa2\x90\x1a\xf7\xf4\x3a\x52\x29\xb3\x43\x9d\xf8\x9f\xe5\xca\xa5\x54\x5d\x95\xf1\xb4\xed\x4c\x76\x85\x09\xe6\x31\x0f\xe2\xa2\x74\xa1\x48\xf7\xc2\xfd\x36\x99\xf6\x93\x07\x59\xd8\xeb\x63\x07\x10\xcf\x60\x43\x27\xd5\x69\x78\xfa\x43\xfc\x49\x8b\x91\xa7\x78\xd2\xa3\xb1\xe7\x9d\xdd\x88\x6a\xf3\x5b\x3a\x40\xa7\xf7\x82\x1d\xf4\xbf\x22\xd5\x10\xa0\xf1\x41\x9b\xf4\xc9\xfa\x46\x56\x7a\x2d\x93\x36\x81\x12\x16\x36\xd6\xdf\x15\x39\xb7\x46\xf5\xcb\xb4\xb9\x1a\xb8\x97\x4d\x73\x59\x10\xbd\x12\x4e\x00\x74\x53\xa1\xd0\xd9\x2f\x84\x13\xbd\x8e\x7e\x44\x76\x61\xe1\x63\x68\x29\x03\x84\xb5\xe4\xbf\xb6\xb7\x3a\x1c\xe6\x1d\x68\x61\x94\x93\x28\x53\x7c\xcb\x88\x10\x2a\x58\x7a\x61\x12\xe0\x12\x1e\xc8\x88\x50\x9a\x7e\xc2\xd6\x6f\x82\xd0\xa1\x50\x79\x3d\x62\xff\xe8\xd9\xb8\xf1\x39\x91\x4d\x42\x4c\x18\x00\x03\x91\x4c\xfd\xa6\x17\xcd\xb6\x77\xd2\x18\x7e\x1a\xcf\x18\x27\x2e\x35\x26\xeb\x1e\x95\x50\x21\xe9\x5b\x61\xec\x52\x54\x31\x91\x38\x8a\x3a\x63\x23\x44\x29\x14\x86\x07\xa7\x41\xd6\xb9\x2e\xda\x52\x60\x0e\x8f\x6e\xa6\xc4\x15\x9d\xd9\xab\x6a\xc1\x8a\xe4\x16\x08\x15\xeb\xb5\xa8\xb8\xe4\x27\x89\x27\x91\x17\x36\x83\x81\xa4\x63\xd5\xe6\x8d\x60\x7e\x5c\x1d\x89\x6f\xbf\xee\x27\xd1\xad\x07\xf8\x78\x86\x4d\x99\x78\x4a\xfe\x50\xbb\xa7\x78\x7d\x34\x4d\x60\x2a\xd6\x5c\x4d\x28\x5d\x9f\xa1\x24\xd3\x19\x7e\x43\x47\xef\x3f\x6c\x3a\x38\xfe\x71\x8c\xa2\x8f\xc8\x9d\x70\x2d\x09\x04\xb7\xf8\x27\x7b\x14\x23\x4c\x53\x87\x71\xe7\x6f\x54\x83\x29\x1d\x65\xc7\xac\x81\x13\x86\xdc\x37\xd5\xd0\xe3\x22\x93\x7e\x60\x9b\x6b\x94\x37\x25\xd0\x65\xe1\x6d\xe4\x3e\xb5\x75\x78\x35\x39\x06\x85\x1b\x7e\xe4\xab\x4b\x5d\x17\xa1\x84\x1d\x68\x94\x50\x2b\x41\x1e\x7c\xf3\x10\xfa\x3e\x17\x5e\x94\x61\x97\x58\x38\x91\x02\xdd\x99\x30\x25\xea\x4c\xfb\xdb\x06\xa5\x6b\x4a\x3d\x9a\x85\xf7\x1d\xc1\x40\x51\x4d\xa3\x94\x42\x47\x27\x6a\xcc\x7c\x8e\x51\x22\xdf\xef\x0d\x96\xac\xfa\x1a\xcf\x00\x8d\x38\x6e\xee\x3a\xd8\xc6\x67\x2f\xf0\x65\x27\x7d\xe8\xf8\xd6\x77\xa2\xf0\x0d\xb8\xa4\x25\x76\xbc\x99\xd6\x39\xbf\x84\xd0\xf6\x04\xb6\x5c\x52\xb6\xe8\x71\xe9\x77\x85\x97\xb7\xe2\xcb\x77\x48\x9c\x8b\xf9\x41\x47\xa5\x52\x2b\x45\x19\x23\x77\xc9\x54\x0d\x02\x48\x94\x70\xc1\x94\xfa\x9e\xd2\x58\xfa\xd8\x4b\x39\xbf\xc7\xd8\x9a\xe5\xbc\x2b\xab\xea\xab\xaa\xbd\x06\x19\x08\x40\x46\xe5\xa7\x12\x84\x91\x3e\x10\x16\x20\x78\x23\x7e\x15\xd0\xe5\x7b\xc8\x69\x39\x39\xba\x17\x11\x5b\x39\x5a\x44\x7c\xdb\x4d\x4d\xa1\xf9\xcf\x23\x1d\x13\x46\x34\xe4\xdb\x41\x8e\xa2\xef\xc5\xed\x5b\x69\xad\xb8\x92\x61\x1d\xcb\x3b\x44\xc4\xfb\x95\xf2\x9e\xc6\x03\xc2\x21\xf1\xb0\x00\xeb\x64\x84\x92\xe8\x2e\xa4\xd7\x2f\x0a\xa9\x43\xaf\x60\x1c\xb8\x62\x80\x59\x07\xb7\x7a\x53\x15\x70\x29\xc1\x86\x34\x74\x9c\x06\xbd\xd0\xad\x0e\x2f\x1a\xe4\x1e\xea\x8f\x99\xa1\xfe\xfe\x3f\x33\x8f\xb3\x33\x20\xc1\xbc\xc4\xbd\x80\xd6\xdc\x7c\x95\x7d\x0d\x62\x8e\x2d\xf2\x65\xd3\xff\x12\x50\x29\xdb\x7a\x06\x5b\xa1\xb6\x56\x5d\x56\x5b\x8c\x81\x85\x2a\x4b\x69\x50\x51\xfe\xde\xbe\xdb\x2d\xa3\x84\x0c\xfb\x64\xa0\x4d\xe1\x07\x79\xae\xa4\x03\x11\x8e\x14\x59\x71\xb4\xd4\x20\x02\xca\xd3\xe9\x84\x72\x4d\x94\xa5\xcc\x1d\x17\x6b\x6e\x29\x8d\x4d\xe1\x42\x4a\xc8\xab\x8d\x75\xd2\xa4\xb4\xbf\x8b\xb5\xcc\x1f\x56\x96\x75\xd5\x12\xcf\x7c\x25\xee\x3f\x6c\x33\xe6\xee\x63\xac\xbf\xe3\xf6\x92\x1d\x37\x46\x44\x1e\x9c\xaf\xb7\x9f\xc6\xd9\x09\xa0\xcc\x0c\x3c\xf6\x2f\xe8\x4c\x28\x5e\x67\xc3\x54\xa2\xad\xec\x3b\x25\xc0\xf4\x21\xc5\x4f\xe6\x93\xb5\x66\x5b\x07\xd5\x6a\x66\xd2\x1f\x8e\x36\x13\xba\x2c\x67\x09\x4c\xb3\x8c\x03\x77\x36\xe5\x3c\x6f\x78\x31\x3e\x06\xb1\xb3\xdb\x04\xbe\x79\xf6\x9c\xe0\x21\x81\xcc\xe7\x74\xe1\x56\x7a\x8f\xd9\xad\x81\x8c\x03\xf4\xc7\x4f\x23\xc2\xd9\x24\xca\x0a\x89\x51\xb2\x4d\x49\x7f\x96\xb7\xaf\xf8\x11\xee\xed\x3b\x4a\xa5\xfc\x95\x9c\xff\x9d\xbe\xac\xb4\x95\x71\x67\x1b\x0b\x08\x64\x52\xfe\x36\xfe\x9a\xa5\x38\x32\x00\x30\x7a\x9f\xe7\xe7\xa7\xf7\x99\xad\xc4\x97\x83\xe6\x64\x0f\x5e\x58\x38\x5c\xd6\x34\x0d\xe9\xd7\x62\x2c\x3e\x85\x64\x9f\xee\xfd\x78\x33\xf0\x67\xa6\x48\xc4\x78\xdf\xdd\x32\xd8\x69\x0d\x2b\x51\x6f\x0f\x10\x72\x0e\x8f\x8a\x04\xf0\xcb\x47\xc5\x34\xe9\x52\x4c\x88\xe0\xec\xf7\x52\x8b\xbf\x88\x68\x2e\xef\x89\x3b\x8f\x0b\xde\xd1\xbb\x9b\x44\x19\xdf\xe0\x3d\xf4\x6e\x38\x33\xa1\x17\x17\xe3\x4e\x82\x27\xb4\xf6\xd2\x5e\x14\x1f\x97\x8c\xe4\xd2\x55\x01\x62\x2d\x8c\xc3\x22\xd5\x2d\xa5\x32\xa0\xea\x42\x7e\x21\x5c\x51\xf5\xa9\x7b\x92\x86\xca\x24\xca\x7c\x9b\xbd\xef\x8b\x87\x9d\xf6\x2c\xcc\xd4\xcd\x17\x74\x4f\x13\x7f\xfc\x34\x0a\xb0\x7a\x47\x36\x9b\x44\xa5\x36\x90\xa9\x84\x3d\x0d\x89\xf1\x44\x10\x2f\x60\xeb\xf0\x0d\xcc\xae\x50\xdc\xc6\x26\x4a\xd4\xeb\xff\x73\xe8\x65\x87\xc5\x5e\xa8\xee\xa5\x04\x2f\x6f\x14\x4b\xf7\xd7\xc4\xfd\x3a\x81\xec\xa6\xc3\x3a\x2c\x61\x8a\x81\xe4\xc7\xec\xfa\x13\x3a\xe7\xcd\x24\xe2\x0e\x78\x78\x91\x5e\x1c\x2a\x28\x01\x94\x8b\xfa\xd9\xd6\x99\x5c\xd7\x37\xe9\x1b\xa7\x45\x9c\xa9\x59\xb8\x1d\xcf\xb8\x4e\xc8\x9a\xdb\xf7\x61\x78\xbc\x0c\xee\x13\x7b\x93\x0a\x5d\x65\xd2\x0d\x92\x09\x67\xf0\x31\x53\x9f\x7c\xca\x0b\x8b\x40\x71\xf8\x9e\xa0\x1f\x83\x8b\x70\xa2\x33\x6d\xd4\x1d\x04\xe8\xad\x3f\xac\xc1\xb3\x7e\x11\x1e\x71\x21\x3e\xd6\xd9\x9e\xa3\xb3\x05\x0e\xe4\x76\xa1\x3e\xba\x6c\x13\x42\x44\xab\x11\xc9\x29\x28\xd7\x4b\x2f\x9a\xd4\x8b\xf2\x51\xa4\x89\xd8\xdf\xe6\x59\x09\x68\xd3\x14\x36\xc1\xe0\x29\x8b\xe9\xe6\x0f\x4d\xf9\x70\xe4\xd6\xcc\x0b\xa4\x6b\xf9\xc0\xbb\xb3\xfe\x29\x1e\xb9\xaf\x3f\x7a\x7d\xc6\xde\x30\x02\x74\xb8\xde\x6a\x1b\x34\x54\x7c\x75\xca\xde\x5b\x85\x7a\x23\x72\x7e\xe6\x12\x8e\xcf\x61\x4e\xa2\x5c\x58\xd9\x9d\x09\x9b\x53\x3b\x06\x91\x4a\x7e\x3e\x98\x2d\xee\x38\x20\xd1\xe6\xc4\xbc\xe3\x88\xc1\xbe\x02\x14\xfe\x5a\xaf\x7c\xd7\xa3\xfb\x41\x02\x27\x67\xdb\x3a\xbd\x52\x1f\xa9\x33\xba\x81\x67\xef\xd9\xb7\xc2\xd9\xf5\xe1\x64\x6e\x00\x95\xae\x3f\xf9\xe1\xa1\x81\x33\xf5\x86\xe0\x48\x18\xfe\x07\xaf\x9e\x8e\x8d\xb0\x31\x15\x1a\x62\x9b\x9d\x72\xa3\xc6\xa5\xdb\x6e\xc3\x57\x63\xaa\xe8\x80\x42\xcd\x65\xd9\x91\x10\xe1\xf7\xee\x57\xb4\x92\x76\x27\xff\x9e\x9f\x3f\x0f\xd3\x90\xcf\xcf\xcf\xfd\x18\x24\xd7\xc1\x9d\x61\x3b\x5f\x8c\xce\x27\x43\x55\x7f\x93\xf4\xa0\xd8\x5f\x1b\x3c\xe2\x89\xaf\x52\x6f\x6a\x04\xde\xae\x49\x51\xf3\xe9\x44\x85\xad\x49\xa6\x6c\xad\x75\xf5\xd0\xfa\x7a\xdc\x58\x05\x6f\xfa\x6f\xda\x5c\x4b\x73\xc7\x50\xc5\xad\x5f\x40\x57\x72\x14\x67\x80\x45\x0b\x17\xe2\xd1\x58\x16\x0b\x78\xf6\xa7\x51\x32\xfd\xcf\x46\x6e\xe4\x69\x89\xba\xec\xe1\x56\x28\x87\x45\x16\x02\x92\xf0\xc2\x8e\x90\x89\x59\xf8\x19\x8f\xd9\xfd\xdd\xa6\xce\x57\xaf\x37\x55\x05\x46\xfe\x43\xe6\xce\x0e\x64\xc1\x22\xf5\x8f\xe7\xff\x09\x7e\x28\x0a\x7e\xad\xc5\x8d\x50\x95\xb8\xac\x64\x7a\xcf\x08\xf8\x11\x1e\xbd\xe9\x2b\xb6\x74\x32\xad\xa6\x64\x9d\x77\x9b\x3c\x9e\x67\x87\x65\x42\xa5\x28\x4e\x4f\xf9\xa6\x15\xa6\xd5\xf1\xb4\x27\xf2\xe7\xa0\xea\x72\x53\x55\xd3\x59\xe2\x91\x25\xcc\xbb\x82\xcd\x97\xb2\xd8\x54\xdd\x69\x12\x82\x15\x4f\xc4\x9b\xb8\xe6\x04\x89\xb5\x0f\x68\xb2\x84\x34\x58\x28\xd2\x0c\xa5\xbf\xf5\xeb\x31\x4b\x21\xd8\x05\x95\xa0\x4e\x98\x3b\xc7\x3d\xc2\xa4\x48\x45\x28\xa5\x37\xae\x6a\xee\x99\x1b\x70\xb3\x1a\x94\xb3\xcd\xf8\x27\xcf\xdb\xf4\xe6\x50\x11\x49\x74\x5d\x6d\x1f\x06\x45\xdd\xe9\xdf\x43\x20\x1a\x4c\x6c\xdc\x35\x9a\xd1\xb9\x3b\x6c\x8b\x06\x22\x1d\x06\x20\x9a\xc7\xa4\x10\x66\x3b\xe3\x71\xbc\x97\x8e\xaa\x8d\x40\xf3\x3b\x91\x5f\x5f\x19\x0c\x2a\xbe\x08\xea\x4f\x40\xbe\x36\x7a\x15\xf2\x62\x1c\xb7\x7a\xc1\xef\x3b\xe1\x36\xd0\x0c\x5f\xe0\x04\x42\xf8\x80\x5f\xf1\x54\x65\x7f\x20\xb0\x33\xa1\x71\x30\x22\xd9\x19\x0a\x33\x32\xd7\x37\xf2\xd8\x50\xd8\xf8\xdb\x04\x6f\x63\x8f\x2c\xac\x45\xad\xf2\x6b\x59\xf8\x6b\x84\x70\x99\x18\xa2\x3e\xe1\x1a\x6a\x21\x2a\xeb\x20\x3b\x4b\x6d\x65\x25\xb9\xc9\x43\x20\x3d\x50\x3a\x67\x30\xdf\x3e\x25\xb3\x9a\x0f\x6e\x19\x0f\xa3\xfc\x43\x9c\x97\x03\xfb\xc3\x3a\x0e\xed\x89\xb3\x3e\x7d\xe4\x4d\xbc\xdf\x0c\x2b\xcd\x5e\x70\x4d\x8e\xbd\x23\x69\xc8\x36\x3c\xa9\xb6\x0c\xbd\x6d\x62\xf2\xc8\xe8\x1d\x2a\x51\x96\xa5\xa1\xf3\xd9\xc7\xd1\x51\xf1\xb6\x3f\xf8\xd3\x3d\x03\x5f\x75\xe4\x4b\xe1\xbd\x67\xe6\x77\xed\x0b\x1d\x85\xbb\x3f\x7f\x01\x0a\xbe\x0d\x7b\x79\x01\xea\xc9\x13\x92\xe1\x4a\xf7\x8c\x10\xd7\x0f\x6a\xa2\x63\x2c\xd9\x16\x71\x61\xdc\x37\xa2\x3e\x22\xff\xdf\x00\xf3\x02\xab\x67\xf5\x36\x00\x00")
+
+func templatesModels_goTplBytes() ([]byte, error) {
+	return bindataRead(
+		_templatesModels_goTpl,
+		"templates/models_go.tpl",
+	)
+}
+
+func templatesModels_goTpl() (*asset, error) {
+	bytes, err := templatesModels_goTplBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "templates/models_go.tpl", size: 14069, mode: os.FileMode(420), modTime: time.Unix(1792415212, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+// Asset loads and returns the asset for the given name.
+// It returns an error if the asset could not be found or
+// could not be loaded.
+func Asset(name string) ([]byte, error) {
+	cannonicalName := strings.Replace(name, "\\", "/", -1)
+	if f, ok := _bindata[cannonicalName]; ok {
+		a, err := f()
+		if err != nil {
+			return nil, fmt.Errorf("Asset %s can't read by error: %v", name, err)
+		}
+		return a.bytes, nil
+	}
+	return nil, fmt.Errorf("Asset %s not found", name)
+}
+
+// MustAsset is like Asset but panics when Asset would return an error.
+// It simplifies safe initialization of global variables.
+func MustAsset(name string) []byte {
+	a, err := Asset(name)
+	if err != nil {
+		panic("asset: Asset(" + name + "): " + err.Error())
+	}
+
+	return a
+}
+
+// AssetInfo loads and returns the asset info for the given name.
+// It returns an error if the asset could not be found or
+// could not be loaded.
+func AssetInfo(name string) (os.FileInfo, error) {
+	cannonicalName := strings.Replace(name, "\\", "/", -1)
+	if f, ok := _bindata[cannonicalName]; ok {
+		a, err := f()
+		if err != nil {
+			return nil, fmt.Errorf("AssetInfo %s can't read by error: %v", name, err)
+		}
+		return a.info, nil
+	}
+	return nil, fmt.Errorf("AssetInfo %s not found", name)
+}
+
+// AssetNames returns the names of the assets.
+func AssetNames() []string {
+	names := make([]string, 0, len(_bindata))
+	for name := range _bindata {
+		names = append(names, name)
+	}
+	return names
+}
+
+// _bindata is a table, holding each asset generator, mapped to its name.
+var _bindata = map[string]func() (*asset, error){
+	"templates/client_rpc_go.tpl": templatesClient_rpc_goTpl,
+	"templates/handler_nethttp_go.tpl": templatesHandler_nethttp_goTpl,
+	"templates/handler_rpc_go.tpl": templatesHandler_rpc_goTpl,
+	"templates/models_go.tpl": templatesModels_goTpl,
+}
+
+// AssetDir returns the file names below a certain
+// directory embedded in the file by go-bindata.
+// For example if you run go-bindata on data/... and data contains the
+// following hierarchy:
+//     data/
+//       foo.txt
+//       img/
+//         a.png
+//         b.png
+// then AssetDir("data") would return []string{"foo.txt", "img"}
+// AssetDir("data/img") would return []string{"a.png", "b.png"}
+// AssetDir("foo.txt") and AssetDir("notexist") would return an error
+// AssetDir("") will return []string{"data"}.
+func AssetDir(name string) ([]string, error) {
+	node := _bintree
+	if len(name) != 0 {
+		cannonicalName := strings.Replace(name, "\\", "/", -1)
+		pathList := strings.Split(cannonicalName, "/")
+		for _, p := range pathList {
+			node = node.Children[p]
+			if node == nil {
+				return nil, fmt.Errorf("Asset %s not found", name)
+			}
+		}
+	}
+	if node.Func != nil {
+		return nil, fmt.Errorf("Asset %s not found", name)
+	}
+	rv := make([]string, 0, len(node.Children))
+	for childName := range node.Children {
+		rv = append(rv, childName)
+	}
+	return rv, nil
+}
+
+type bintree struct {
+	Func     func() (*asset, error)
+	Children map[string]*bintree
+}
+var _bintree = &bintree{nil, map[string]*bintree{
+	"templates": &bintree{nil, map[string]*bintree{
+		"client_rpc_go.tpl": &bintree{templatesClient_rpc_goTpl, map[string]*bintree{}},
+		"handler_nethttp_go.tpl": &bintree{templatesHandler_nethttp_goTpl, map[string]*bintree{}},
+		"handler_rpc_go.tpl": &bintree{templatesHandler_rpc_goTpl, map[string]*bintree{}},
+		"models_go.tpl": &bintree{templatesModels_goTpl, map[string]*bintree{}},
+	}},
+}}
+
+// RestoreAsset restores an asset under the given directory
+func RestoreAsset(dir, name string) error {
+	data, err := Asset(name)
+	if err != nil {
+		return err
+	}
+	info, err := AssetInfo(name)
+	if err != nil {
+		return err
+	}
+	err = os.MkdirAll(_filePath(dir, filepath.Dir(name)), os.FileMode(0755))
+	if err != nil {
+		return err
+	}
+	err = ioutil.WriteFile(_filePath(dir, name), data, info.Mode())
+	if err != nil {
+		return err
+	}
+	err = os.Chtimes(_filePath(dir, name), info.ModTime(), info.ModTime())
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// RestoreAssets restores an asset under the given directory recursively
+func RestoreAssets(dir, name string) error {
+	children, err := AssetDir(name)
+	// File
+	if err != nil {
+		return RestoreAsset(dir, name)
+	}
+	// Dir
+	for _, child := range children {
+		err = RestoreAssets(dir, filepath.Join(name, child))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func _filePath(dir, name string) string {
+	cannonicalName := strings.Replace(name, "\\", "/", -1)
+	return filepath.Join(append([]string{dir}, strings.Split(cannonicalName, "/")...)...)
+}
+
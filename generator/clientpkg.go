@@ -0,0 +1,200 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/printer"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// clientPackage collects declarations required by a standalone client package:
+// types declared in the service package are copied, types from any other
+// packages are imported.
+type clientPackage struct {
+	resolver *typeResolver
+	imports  map[string]string
+	types    map[string]*typeDecl
+	order    []string
+}
+
+// newClientPackage walks params and results of all methods of the service interface
+// and collects types they reference.
+func newClientPackage(iface *MethodsCollection, srcDir string) (*clientPackage, error) {
+	c := &clientPackage{
+		resolver: newTypeResolver(srcDir),
+		imports:  make(map[string]string),
+		types:    make(map[string]*typeDecl),
+	}
+	err := iface.ForEachMethod(func(m *Method) error {
+		scope, err := c.resolver.methodScope(m)
+		if err != nil {
+			return err
+		}
+		for _, p := range m.Params {
+			if err := c.walkType(scope, p.expr); err != nil {
+				return fmt.Errorf("%s(%s): %v", m.Name, p.Name, err)
+			}
+		}
+		for _, r := range m.Res {
+			if err := c.walkType(scope, r.expr); err != nil {
+				return fmt.Errorf("%s() %s: %v", m.Name, r.Name, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func (c *clientPackage) walkType(scope *typeScope, expr ast.Expr) error {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		if isPredeclared(e.Name) {
+			return nil
+		}
+		return c.copyType(scope, e)
+	case *ast.SelectorExpr:
+		return c.importPkg(scope, e)
+	case *ast.StarExpr:
+		return c.walkType(scope, e.X)
+	case *ast.ParenExpr:
+		return c.walkType(scope, e.X)
+	case *ast.Ellipsis:
+		return c.walkType(scope, e.Elt)
+	case *ast.ArrayType:
+		return c.walkType(scope, e.Elt)
+	case *ast.MapType:
+		if err := c.walkType(scope, e.Key); err != nil {
+			return err
+		}
+		return c.walkType(scope, e.Value)
+	case *ast.ChanType:
+		return c.walkType(scope, e.Value)
+	case *ast.StructType:
+		return c.walkFields(scope, e.Fields)
+	case *ast.InterfaceType:
+		return c.walkFields(scope, e.Methods)
+	case *ast.FuncType:
+		if err := c.walkFields(scope, e.Params); err != nil {
+			return err
+		}
+		return c.walkFields(scope, e.Results)
+	}
+	return nil
+}
+
+func (c *clientPackage) walkFields(scope *typeScope, fields *ast.FieldList) error {
+	if fields == nil {
+		return nil
+	}
+	for _, field := range fields.List {
+		if err := c.walkType(scope, field.Type); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *clientPackage) copyType(scope *typeScope, id *ast.Ident) error {
+	decl, err := c.resolver.lookup(scope, id)
+	if err != nil || decl == nil {
+		return err
+	}
+	name := decl.Spec.Name.Name
+	if prev, ok := c.types[name]; ok {
+		if prev != decl {
+			return fmt.Errorf("type %s is declared in both %s and %s",
+				name, prev.Pkg.ImportPath, decl.Pkg.ImportPath)
+		}
+		return nil
+	}
+	for _, method := range []string{"MarshalJSON", "UnmarshalJSON", "MarshalText", "UnmarshalText"} {
+		if decl.HasMethod(method) {
+			return fmt.Errorf("type %s has a custom codec and can't be copied into the client package, "+
+				"move it into a separate package", decl.FullName())
+		}
+	}
+	c.types[name] = decl
+	c.order = append(c.order, name)
+	return c.walkType(decl.Scope(), decl.Spec.Type)
+}
+
+func (c *clientPackage) importPkg(scope *typeScope, sel *ast.SelectorExpr) error {
+	pkgIdent, ok := sel.X.(*ast.Ident)
+	if !ok {
+		return nil
+	}
+	importPath, err := c.resolver.importPathOf(scope, pkgIdent.Name)
+	if err != nil {
+		return err
+	}
+	for otherPath, otherName := range c.imports {
+		if otherName == pkgIdent.Name && otherPath != importPath {
+			return fmt.Errorf("package name %s is used for both %s and %s",
+				pkgIdent.Name, otherPath, importPath)
+		}
+	}
+	c.imports[importPath] = pkgIdent.Name
+	return nil
+}
+
+// ImportsBody returns import specs of the referenced packages,
+// stdlib packages go first and the rest are separated by a blank line.
+func (c *clientPackage) ImportsBody() string {
+	paths := make([]string, 0, len(c.imports))
+	for importPath := range c.imports {
+		paths = append(paths, importPath)
+	}
+	sort.Strings(paths)
+	std := new(bytes.Buffer)
+	other := new(bytes.Buffer)
+	for _, importPath := range paths {
+		buf := other
+		if idx, err := c.resolver.loadPkg(importPath); err == nil && idx.Goroot {
+			buf = std
+		}
+		if name := c.imports[importPath]; name != path.Base(importPath) {
+			fmt.Fprintf(buf, "%s %s\n", name, strconv.Quote(importPath))
+			continue
+		}
+		fmt.Fprintf(buf, "%s\n", strconv.Quote(importPath))
+	}
+	if other.Len() == 0 {
+		return std.String()
+	}
+	return std.String() + "\n" + other.String()
+}
+
+// TypesBody returns declarations of the copied types.
+func (c *clientPackage) TypesBody() string {
+	buf := new(bytes.Buffer)
+	for _, name := range c.order {
+		decl := c.types[name]
+		fmt.Fprintf(buf, "// %s is a copy of %s.\n", name, decl.FullName())
+		fmt.Fprint(buf, "type ")
+		printer.Fprint(buf, decl.Pkg.FileSet, &printer.CommentedNode{
+			Node:     decl.Spec,
+			Comments: decl.File.Comments,
+		})
+		fmt.Fprint(buf, "\n\n")
+	}
+	return buf.String()
+}
+
+// clientPackageName returns a valid package name derived from the output dir.
+func clientPackageName(dir string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' {
+			return unicode.ToLower(r)
+		}
+		return -1
+	}, filepath.Base(dir))
+}
@@ -0,0 +1,292 @@
+package generator
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Change is a single difference between two versions of the service interface.
+type Change struct {
+	Breaking bool
+	Where    string
+	Desc     string
+}
+
+func (c Change) String() string {
+	if c.Breaking {
+		return fmt.Sprintf("breaking:   %s: %s", c.Where, c.Desc)
+	}
+	return fmt.Sprintf("compatible: %s: %s", c.Where, c.Desc)
+}
+
+// DiffSchemas compares the wire format of two versions of the service interface and
+// classifies changes. A change is breaking when a client or handler generated from
+// the base version would not interoperate with the one generated from the head version.
+func DiffSchemas(base, head *Schema) []Change {
+	d := &schemaDiff{
+		base:    base,
+		head:    head,
+		visited: make(map[string]bool),
+	}
+	for _, bm := range base.Methods {
+		hm, ok := head.Method(bm.Name)
+		if !ok {
+			d.breaking(bm.Name, "method removed")
+			continue
+		}
+		d.diffParams(bm.Name, bm.Params, hm.Params)
+		d.diffResults(bm.Name, bm.Res, hm.Res)
+	}
+	for _, hm := range head.Methods {
+		if _, ok := base.Method(hm.Name); !ok {
+			d.compatible(hm.Name, "method added")
+		}
+	}
+	return d.changes
+}
+
+type schemaDiff struct {
+	base    *Schema
+	head    *Schema
+	visited map[string]bool
+	changes []Change
+}
+
+func (d *schemaDiff) breaking(where, format string, args ...interface{}) {
+	d.changes = append(d.changes, Change{
+		Breaking: true,
+		Where:    where,
+		Desc:     fmt.Sprintf(format, args...),
+	})
+}
+
+func (d *schemaDiff) compatible(where, format string, args ...interface{}) {
+	d.changes = append(d.changes, Change{
+		Where: where,
+		Desc:  fmt.Sprintf(format, args...),
+	})
+}
+
+func (d *schemaDiff) diffParams(method string, base, head []FieldSchema) {
+	removed, added := d.diffFields(method, "param", base, head)
+	for _, f := range removed {
+		if renamed, ok := renamedField(f, base, head, added); ok {
+			d.breaking(method, "param %s renamed to %s, JSON key changed", f.Name, renamed.Name)
+			delete(added, renamed.Key)
+			d.diffTypes(method, "param "+renamed.Key, f.Type, renamed.Type)
+			continue
+		}
+		d.breaking(method, "param %s removed, its value is ignored by the handler", f.Name)
+	}
+	for _, f := range head {
+		if _, ok := added[f.Key]; ok {
+			d.compatible(method, "param %s added, old clients will send a zero value", f.Name)
+		}
+	}
+}
+
+func (d *schemaDiff) diffResults(method string, base, head []FieldSchema) {
+	if reorderedRets(base, head) {
+		d.breaking(method, "unnamed results reordered, _retN keys changed")
+		// the types are the same, but named types they refer to may have changed
+		for _, f := range base {
+			d.diffTypes(method, "result "+f.Key, f.Type, f.Type)
+		}
+		return
+	}
+	removed, added := d.diffFields(method, "result", base, head)
+	for _, f := range removed {
+		if renamed, ok := renamedField(f, base, head, added); ok {
+			d.breaking(method, "result %s renamed to %s, JSON key changed", f.Key, renamed.Key)
+			delete(added, renamed.Key)
+			d.diffTypes(method, "result "+renamed.Key, f.Type, renamed.Type)
+			continue
+		}
+		d.breaking(method, "result %s removed, old clients will get a zero value", f.Key)
+	}
+	for _, f := range head {
+		if _, ok := added[f.Key]; ok {
+			d.compatible(method, "result %s added", f.Key)
+		}
+	}
+}
+
+// diffFields reports type changes of fields with matching keys, returns fields
+// that exist in base only and a set of fields that exist in head only.
+func (d *schemaDiff) diffFields(where, kind string, base, head []FieldSchema) (removed []FieldSchema, added map[string]FieldSchema) {
+	added = make(map[string]FieldSchema, len(head))
+	for _, f := range head {
+		added[f.Key] = f
+	}
+	for _, bf := range base {
+		hf, ok := added[bf.Key]
+		if !ok {
+			removed = append(removed, bf)
+			continue
+		}
+		delete(added, bf.Key)
+		d.diffTypes(where, kind+" "+bf.Key, bf.Type, hf.Type)
+	}
+	return removed, added
+}
+
+// diffTypes compares canonical type expressions, descending into named types,
+// including map keys and fields of anonymous structs.
+func (d *schemaDiff) diffTypes(where, what, base, head string) {
+	if base != head {
+		d.breaking(where, "%s type changed from %s to %s", what, base, head)
+		return
+	}
+	for _, name := range typeNames(base) {
+		bt, ok := d.base.Types[name]
+		if !ok {
+			continue
+		}
+		ht, ok := d.head.Types[name]
+		if !ok {
+			continue
+		}
+		d.diffNamed(bt, ht)
+	}
+}
+
+func (d *schemaDiff) diffNamed(base, head *TypeSchema) {
+	if d.visited[base.Name] {
+		return
+	}
+	d.visited[base.Name] = true
+	where := base.Name
+	switch {
+	case base.Custom || head.Custom:
+		if base.Custom != head.Custom {
+			d.breaking(where, "custom JSON codec added or removed")
+		}
+	case len(base.Underlying) > 0 || len(head.Underlying) > 0:
+		d.diffTypes(where, "underlying", base.Underlying, head.Underlying)
+	default:
+		removed, added := d.diffFields(where, "field", base.Fields, head.Fields)
+		for _, f := range removed {
+			d.breaking(where, "field %s removed or renamed, JSON key %q is gone", f.Name, f.Key)
+		}
+		for _, f := range head.Fields {
+			if _, ok := added[f.Key]; ok {
+				d.compatible(where, "field %s added", f.Name)
+			}
+		}
+	}
+}
+
+// renamedField finds a field that has been added at the same position
+// with the same type, it's likely to be the renamed one.
+func renamedField(f FieldSchema, base, head []FieldSchema, added map[string]FieldSchema) (FieldSchema, bool) {
+	pos := -1
+	for i := range base {
+		if base[i].Key == f.Key {
+			pos = i
+			break
+		}
+	}
+	if pos < 0 || pos >= len(head) {
+		return FieldSchema{}, false
+	}
+	candidate := head[pos]
+	if _, ok := added[candidate.Key]; !ok || candidate.Type != f.Type {
+		return FieldSchema{}, false
+	}
+	return candidate, true
+}
+
+// reorderedRets reports whether the unnamed results have the same types but in a different order.
+func reorderedRets(base, head []FieldSchema) bool {
+	bTypes := unnamedTypes(base)
+	hTypes := unnamedTypes(head)
+	if len(bTypes) < 2 || len(bTypes) != len(hTypes) {
+		return false
+	}
+	if strings.Join(bTypes, ",") == strings.Join(hTypes, ",") {
+		return false
+	}
+	sort.Strings(bTypes)
+	sort.Strings(hTypes)
+	return strings.Join(bTypes, ",") == strings.Join(hTypes, ",")
+}
+
+func unnamedTypes(fields []FieldSchema) []string {
+	var types []string
+	for _, f := range fields {
+		if len(f.Name) == 0 {
+			types = append(types, f.Type)
+		}
+	}
+	return types
+}
+
+// typeNames strips pointers, slices and maps from a canonical type, returning
+// the names of the element types: both the key and the value ones for maps,
+// and types of all fields for anonymous structs.
+func typeNames(typ string) []string {
+	for {
+		switch {
+		case strings.HasPrefix(typ, "*"):
+			typ = typ[1:]
+		case strings.HasPrefix(typ, "..."):
+			typ = typ[3:]
+		case strings.HasPrefix(typ, "map["):
+			end := matchingBracket(typ, 3, '[', ']')
+			return append(typeNames(typ[4:end]), typeNames(typ[end+1:])...)
+		case strings.HasPrefix(typ, "["):
+			typ = typ[strings.Index(typ, "]")+1:]
+		case strings.HasPrefix(typ, "struct{"):
+			var names []string
+			body := typ[len("struct{"):matchingBracket(typ, len("struct"), '{', '}')]
+			for _, field := range splitFields(body) {
+				if i := strings.Index(field, " "); i >= 0 {
+					names = append(names, typeNames(field[i+1:])...)
+				}
+			}
+			return names
+		default:
+			return []string{typ}
+		}
+	}
+}
+
+// splitFields splits fields of an anonymous struct type, skipping nested ones.
+func splitFields(body string) []string {
+	var fields []string
+	depth, start := 0, 0
+	for i := 0; i < len(body); i++ {
+		switch body[i] {
+		case '{', '[':
+			depth++
+		case '}', ']':
+			depth--
+		case ';':
+			if depth == 0 {
+				fields = append(fields, strings.TrimSpace(body[start:i]))
+				start = i + 1
+			}
+		}
+	}
+	if rest := strings.TrimSpace(body[start:]); len(rest) > 0 {
+		fields = append(fields, rest)
+	}
+	return fields
+}
+
+func matchingBracket(s string, open int, left, right byte) int {
+	depth := 0
+	for i := open; i < len(s); i++ {
+		switch s[i] {
+		case left:
+			depth++
+		case right:
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return len(s) - 1
+}
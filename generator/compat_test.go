@@ -0,0 +1,170 @@
+package generator
+
+import (
+	"reflect"
+	"testing"
+)
+
+const testPkg = "github.com/astranet/meshRPC/example/shop"
+
+func testItemType(nameType string) *TypeSchema {
+	return &TypeSchema{
+		Name: testPkg + ".Item",
+		Fields: []FieldSchema{
+			{Key: "name", Name: "Name", Type: nameType},
+		},
+	}
+}
+
+func testSchema(method MethodSchema, types ...*TypeSchema) *Schema {
+	s := &Schema{
+		Methods: []MethodSchema{method},
+		Types:   make(map[string]*TypeSchema),
+	}
+	for _, t := range types {
+		s.Types[t.Name] = t
+	}
+	return s
+}
+
+func TestDiffSchemas(t *testing.T) {
+	item := testPkg + ".Item"
+	tests := []struct {
+		name string
+		base *Schema
+		head *Schema
+		want []string
+	}{{
+		name: "no changes",
+		base: testSchema(MethodSchema{Name: "Add", Params: []FieldSchema{{Key: "item", Name: "item", Type: "*" + item}}},
+			testItemType("string")),
+		head: testSchema(MethodSchema{Name: "Add", Params: []FieldSchema{{Key: "item", Name: "item", Type: "*" + item}}},
+			testItemType("string")),
+	}, {
+		name: "field type changed",
+		base: testSchema(MethodSchema{Name: "Add", Params: []FieldSchema{{Key: "item", Name: "item", Type: "*" + item}}},
+			testItemType("string")),
+		head: testSchema(MethodSchema{Name: "Add", Params: []FieldSchema{{Key: "item", Name: "item", Type: "*" + item}}},
+			testItemType("int")),
+		want: []string{
+			"breaking:   " + item + ": field name type changed from string to int",
+		},
+	}, {
+		name: "param renamed along with a field type change",
+		base: testSchema(MethodSchema{Name: "Add", Params: []FieldSchema{{Key: "item", Name: "item", Type: "*" + item}}},
+			testItemType("string")),
+		head: testSchema(MethodSchema{Name: "Add", Params: []FieldSchema{{Key: "item2", Name: "item2", Type: "*" + item}}},
+			testItemType("int")),
+		want: []string{
+			"breaking:   Add: param item renamed to item2, JSON key changed",
+			"breaking:   " + item + ": field name type changed from string to int",
+		},
+	}, {
+		name: "result renamed along with a field type change",
+		base: testSchema(MethodSchema{Name: "Get", Res: []FieldSchema{{Key: "item", Name: "item", Type: item}}},
+			testItemType("string")),
+		head: testSchema(MethodSchema{Name: "Get", Res: []FieldSchema{{Key: "it", Name: "it", Type: item}}},
+			testItemType("int")),
+		want: []string{
+			"breaking:   Get: result item renamed to it, JSON key changed",
+			"breaking:   " + item + ": field name type changed from string to int",
+		},
+	}, {
+		name: "results reordered along with a field type change",
+		base: testSchema(MethodSchema{Name: "Get", Res: []FieldSchema{
+			{Key: "_ret0", Type: "[]" + item},
+			{Key: "_ret1", Type: "int"},
+		}}, testItemType("string")),
+		head: testSchema(MethodSchema{Name: "Get", Res: []FieldSchema{
+			{Key: "_ret0", Type: "int"},
+			{Key: "_ret1", Type: "[]" + item},
+		}}, testItemType("int")),
+		want: []string{
+			"breaking:   Get: unnamed results reordered, _retN keys changed",
+			"breaking:   " + item + ": field name type changed from string to int",
+		},
+	}, {
+		name: "map key type changed",
+		base: testSchema(MethodSchema{Name: "Count", Params: []FieldSchema{{Key: "counts", Name: "counts", Type: "map[" + item + "]int"}}},
+			testItemType("string")),
+		head: testSchema(MethodSchema{Name: "Count", Params: []FieldSchema{{Key: "counts", Name: "counts", Type: "map[" + item + "]int"}}},
+			testItemType("int")),
+		want: []string{
+			"breaking:   " + item + ": field name type changed from string to int",
+		},
+	}, {
+		name: "anonymous struct field type changed",
+		base: testSchema(MethodSchema{Name: "Add", Params: []FieldSchema{{Key: "req", Name: "req", Type: "struct{id string; items map[string][]*" + item + "}"}}},
+			testItemType("string")),
+		head: testSchema(MethodSchema{Name: "Add", Params: []FieldSchema{{Key: "req", Name: "req", Type: "struct{id string; items map[string][]*" + item + "}"}}},
+			testItemType("int")),
+		want: []string{
+			"breaking:   " + item + ": field name type changed from string to int",
+		},
+	}, {
+		name: "param type changed",
+		base: testSchema(MethodSchema{Name: "Add", Params: []FieldSchema{{Key: "n", Name: "n", Type: "int"}}}),
+		head: testSchema(MethodSchema{Name: "Add", Params: []FieldSchema{{Key: "n", Name: "n", Type: "string"}}}),
+		want: []string{
+			"breaking:   Add: param n type changed from int to string",
+		},
+	}, {
+		name: "param added",
+		base: testSchema(MethodSchema{Name: "Add", Params: []FieldSchema{{Key: "a", Name: "a", Type: "int"}}}),
+		head: testSchema(MethodSchema{Name: "Add", Params: []FieldSchema{
+			{Key: "a", Name: "a", Type: "int"},
+			{Key: "b", Name: "b", Type: "int"},
+		}}),
+		want: []string{
+			"compatible: Add: param b added, old clients will send a zero value",
+		},
+	}, {
+		name: "field added and removed",
+		base: testSchema(MethodSchema{Name: "Add", Params: []FieldSchema{{Key: "item", Name: "item", Type: item}}},
+			&TypeSchema{Name: item, Fields: []FieldSchema{{Key: "a", Name: "A", Type: "int"}}}),
+		head: testSchema(MethodSchema{Name: "Add", Params: []FieldSchema{{Key: "item", Name: "item", Type: item}}},
+			&TypeSchema{Name: item, Fields: []FieldSchema{{Key: "b", Name: "B", Type: "int"}}}),
+		want: []string{
+			"breaking:   " + item + `: field A removed or renamed, JSON key "a" is gone`,
+			"compatible: " + item + ": field B added",
+		},
+	}, {
+		name: "method removed and added",
+		base: testSchema(MethodSchema{Name: "Add"}),
+		head: testSchema(MethodSchema{Name: "Put"}),
+		want: []string{
+			"breaking:   Add: method removed",
+			"compatible: Put: method added",
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, c := range DiffSchemas(tt.base, tt.head) {
+				got = append(got, c.String())
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DiffSchemas() =\n%q\nwant\n%q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTypeNames(t *testing.T) {
+	tests := []struct {
+		typ  string
+		want []string
+	}{
+		{"string", []string{"string"}},
+		{"*a.T", []string{"a.T"}},
+		{"...[]*a.T", []string{"a.T"}},
+		{"[4]a.T", []string{"a.T"}},
+		{"map[a.K]map[string]*a.V", []string{"a.K", "string", "a.V"}},
+		{"struct{x a.T; y struct{z map[a.K]int}}", []string{"a.T", "a.K", "int"}},
+	}
+	for _, tt := range tests {
+		if got := typeNames(tt.typ); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("typeNames(%q) = %q, want %q", tt.typ, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,326 @@
+// Package generator renders the RPC handler and the client of a service interface,
+// it's the library behind the meshRPC tool. Build tools and other code generators
+// may use it directly, instead of running the tool:
+//
+//	res, err := generator.Generate("greeter/service", &generator.Options{
+//		PackageName: "greeter",
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	for _, f := range res.Files {
+//		contents, err := f.Format()
+//		// write contents into f.Path
+//	}
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"golang.org/x/tools/imports"
+
+	"github.com/astranet/meshRPC/validate"
+)
+
+const (
+	// ServerHTTPServe generates handlers with func(*httpserve.Context) httpserve.Response methods.
+	ServerHTTPServe = "httpserve"
+	// ServerNetHTTP generates handlers with func(http.ResponseWriter, *http.Request) methods.
+	ServerNetHTTP = "nethttp"
+)
+
+// Options describe how a service interface is exposed.
+type Options struct {
+	// PackageName is the name of the package with the service interface.
+	PackageName string
+	// FeaturePrefix is an optional prefix to distinguish multiple service interfaces
+	// in the same package, the interface is named <FeaturePrefix>Service.
+	FeaturePrefix string
+	// Server is the handler flavour: ServerHTTPServe (default) or ServerNetHTTP.
+	Server string
+	// ClientOut is the dir of a standalone client package, if set.
+	ClientOut string
+	// Templates to render files with, the embedded ones are used by default.
+	// See LoadTemplates.
+	Templates *template.Template
+}
+
+// File is a rendered source file.
+type File struct {
+	// Path is the absolute path of the file.
+	Path string
+	// Contents is the template output, not formatted yet.
+	Contents []byte
+}
+
+// Format returns the contents formatted as goimports does.
+func (f File) Format() ([]byte, error) {
+	return imports.Process(f.Path, f.Contents, nil)
+}
+
+// Result of the generation.
+type Result struct {
+	// Interface is the exposed service interface.
+	Interface *MethodsCollection
+	// Schema is the wire format of the service interface.
+	Schema *Schema
+	// Files are the handler, then the client.
+	Files []File
+}
+
+// Generate renders the RPC handler and the client of the service interface
+// declared in srcDir. Files are not written, it's up to the caller.
+func Generate(srcDir string, opt *Options) (*Result, error) {
+	if opt == nil {
+		opt = &Options{}
+	}
+	srcDir, err := filepath.Abs(srcDir)
+	if err != nil {
+		return nil, err
+	}
+	tpls := opt.Templates
+	if tpls == nil {
+		if tpls, err = LoadTemplates(""); err != nil {
+			return nil, fmt.Errorf("failed to load templates: %v", err)
+		}
+	}
+	var handlerTemplate string
+	switch opt.Server {
+	case ServerHTTPServe, "":
+		handlerTemplate = rpcHandlerTemplate
+	case ServerNetHTTP:
+		handlerTemplate = netHTTPHandlerTemplate
+	default:
+		return nil, fmt.Errorf("unknown server kind %q, expected %s or %s", opt.Server, ServerHTTPServe, ServerNetHTTP)
+	}
+	ctx := &TemplateContext{
+		PackageName:   strings.ToLower(opt.PackageName),
+		FeaturePrefix: strings.Title(opt.FeaturePrefix),
+
+		RPCHandlerPrivateName: rpcHandlerPrivateName(opt.FeaturePrefix),
+		RPCClientPrivateName:  rpcClientPrivateName(opt.FeaturePrefix),
+	}
+	filePrefix := strings.ToLower(opt.FeaturePrefix) + "_"
+	if len(opt.FeaturePrefix) == 0 {
+		filePrefix = ""
+	}
+	ifaceName := fmt.Sprintf("%s.%sService", ctx.PackageName, ctx.FeaturePrefix)
+	ctx.ServiceName = ifaceName
+	iface, err := NewMethodsCollection(ifaceName, srcDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to locate %s interface: %v", ifaceName, err)
+	}
+	schema, err := NewSchema(iface, srcDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to inspect %s interface: %v", iface.ID, err)
+	}
+	ctx.Fingerprint = schema.Fingerprint()
+	if err := checkOneway(iface); err != nil {
+		return nil, err
+	}
+	if err := checkValidateRules(iface); err != nil {
+		return nil, err
+	}
+	for i := range iface.Methods {
+		ctx.Methods = append(ctx.Methods, &iface.Methods[i])
+	}
+	res := &Result{
+		Interface: iface,
+		Schema:    schema,
+	}
+	handler, err := ctx.Render(tpls, handlerTemplate)
+	if err != nil {
+		return nil, err
+	}
+	res.Files = append(res.Files, File{
+		Path:     filepath.Join(srcDir, filePrefix+"handler_gen.go"),
+		Contents: handler,
+	})
+	clientDir := srcDir
+	if len(opt.ClientOut) > 0 {
+		if clientDir, err = filepath.Abs(opt.ClientOut); err != nil {
+			return nil, err
+		}
+		clientPkg, err := newClientPackage(iface, srcDir)
+		if err != nil {
+			return nil, fmt.Errorf("failed to collect types of %s interface: %v", iface.ID, err)
+		}
+		clientCtx := *ctx
+		clientCtx.PackageName = clientPackageName(clientDir)
+		clientCtx.StandaloneClient = true
+		clientCtx.ClientImportsBody = clientPkg.ImportsBody()
+		clientCtx.ClientTypesBody = clientPkg.TypesBody()
+		ctx = &clientCtx
+	}
+	client, err := ctx.Render(tpls, rpcClientTemplate)
+	if err != nil {
+		return nil, err
+	}
+	res.Files = append(res.Files, File{
+		Path:     filepath.Join(clientDir, filePrefix+"client_gen.go"),
+		Contents: client,
+	})
+	return res, nil
+}
+
+// checkOneway returns an error if a oneway method has results,
+// since its calls are acknowledged before the service is called.
+func checkOneway(iface *MethodsCollection) error {
+	for _, m := range iface.Methods {
+		if m.Oneway && len(m.Res) > 0 {
+			return fmt.Errorf("%s.%s is marked oneway, but has results", iface.ID, m.Name)
+		}
+	}
+	return nil
+}
+
+// checkValidateRules returns an error if validate rules of a param are unknown or malformed,
+// since the handler would reject every call of the method.
+func checkValidateRules(iface *MethodsCollection) error {
+	for _, m := range iface.Methods {
+		for _, p := range m.Params {
+			if len(p.Validate) == 0 {
+				continue
+			}
+			if err := validate.CheckRules(p.Validate); err != nil {
+				where := fmt.Sprintf("%s.%s param %s", iface.ID, m.Name, p.Name)
+				if m.src != nil && p.expr != nil {
+					where = fmt.Sprintf("%s: %s", m.src.Pkg.Position(p.expr.Pos()), where)
+				}
+				return fmt.Errorf("%s: %v", where, err)
+			}
+		}
+	}
+	return nil
+}
+
+type TemplateContext struct {
+	PackageName   string
+	FeaturePrefix string
+	// ServiceName is the name of the service interface in its package, e.g. "greeter.Service".
+	ServiceName string
+
+	RPCHandlerPrivateName string
+	RPCClientPrivateName  string
+
+	// Fingerprint is a hash of the service interface wire format.
+	Fingerprint string
+
+	// Methods of the service interface, see Method and Param for helpers
+	// that are available in templates.
+	Methods []*Method
+
+	// StandaloneClient is set when the client is generated into a standalone package,
+	// so it must carry own copies of the service interface, models and types
+	// declared in the service package.
+	StandaloneClient bool
+	// ClientImportsBody contains imports of packages the copied types refer to.
+	ClientImportsBody string
+	// ClientTypesBody contains the copied types.
+	ClientTypesBody string
+}
+
+// HasOneway reports whether any of the methods is oneway, so the handler needs a worker pool.
+func (t *TemplateContext) HasOneway() bool {
+	for _, m := range t.Methods {
+		if m.Oneway {
+			return true
+		}
+	}
+	return false
+}
+
+//go:generate go-bindata -o bindata.go -pkg generator templates/
+const (
+	rpcHandlerTemplate     = "handler_rpc_go.tpl"
+	netHTTPHandlerTemplate = "handler_nethttp_go.tpl"
+	rpcClientTemplate      = "client_rpc_go.tpl"
+)
+
+var templateFuncs = template.FuncMap{
+	"title":   strings.Title,
+	"lower":   strings.ToLower,
+	"upper":   strings.ToUpper,
+	"comment": comment,
+}
+
+// comment turns the text into a line comment, e.g. for Doc of methods and params.
+func comment(text string) string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		if len(line) == 0 {
+			lines[i] = "//"
+			continue
+		}
+		lines[i] = "// " + line
+	}
+	return strings.Join(lines, "\n")
+}
+
+// LoadTemplates parses all embedded templates into a single set, so they may use
+// partials of each other. A template is overridden by a file with the same name
+// in dir, other *.tpl files in dir are parsed too, e.g. to define own partials.
+func LoadTemplates(dir string) (*template.Template, error) {
+	names, err := AssetDir("templates")
+	if err != nil {
+		return nil, err
+	}
+	contents := make(map[string][]byte, len(names))
+	for _, name := range names {
+		contents[name] = MustAsset("templates/" + name)
+	}
+	if len(dir) > 0 {
+		overrides, err := filepath.Glob(filepath.Join(dir, "*.tpl"))
+		if err != nil {
+			return nil, err
+		} else if len(overrides) == 0 {
+			return nil, fmt.Errorf("no *.tpl files found in %s", dir)
+		}
+		for _, path := range overrides {
+			data, err := ioutil.ReadFile(path)
+			if err != nil {
+				return nil, err
+			}
+			name := filepath.Base(path)
+			if _, ok := contents[name]; !ok {
+				names = append(names, name)
+			}
+			contents[name] = data
+		}
+	}
+	tpls := template.New("").Funcs(templateFuncs)
+	for _, name := range names {
+		if _, err := tpls.New(name).Parse(string(contents[name])); err != nil {
+			return nil, err
+		}
+	}
+	return tpls, nil
+}
+
+// Render executes the named template of the set with the context.
+func (t *TemplateContext) Render(tpls *template.Template, name string) ([]byte, error) {
+	buf := new(bytes.Buffer)
+	if err := tpls.ExecuteTemplate(buf, name, t); err != nil {
+		return nil, fmt.Errorf("failed to render %s: %v", name, err)
+	}
+	return buf.Bytes(), nil
+}
+
+func rpcHandlerPrivateName(featurePrefix string) string {
+	if len(featurePrefix) == 0 {
+		return "rpcHandler"
+	}
+	return strings.ToLower(string(featurePrefix[0])) + featurePrefix[1:] + "RPCHandler"
+}
+
+func rpcClientPrivateName(featurePrefix string) string {
+	if len(featurePrefix) == 0 {
+		return "rpcClient"
+	}
+	return strings.ToLower(string(featurePrefix[0])) + featurePrefix[1:] + "RPCClient"
+}
@@ -0,0 +1,255 @@
+package generator
+
+import (
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// generateGreeter generates the handler and the client of the greeter example,
+// it returns formatted contents by file path.
+func generateGreeter(t *testing.T, opt *Options) map[string]string {
+	t.Helper()
+	srcDir, err := filepath.Abs(filepath.Join("..", "example", "greeter", "service"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	opt.PackageName = "greeter"
+	res, err := Generate(srcDir, opt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	files := make(map[string]string, len(res.Files))
+	for _, f := range res.Files {
+		contents, err := f.Format()
+		if err != nil {
+			t.Fatalf("failed to format %s: %v", f.Path, err)
+		}
+		if _, err := parser.ParseFile(token.NewFileSet(), f.Path, contents, 0); err != nil {
+			t.Fatalf("failed to parse %s: %v", f.Path, err)
+		}
+		files[f.Path] = string(contents)
+	}
+	return files
+}
+
+// checkContains reports snippets missing from the contents of the file.
+func checkContains(t *testing.T, name, contents string, snippets ...string) {
+	t.Helper()
+	for _, s := range snippets {
+		if !strings.Contains(contents, s) {
+			t.Errorf("%s doesn't contain %q", name, s)
+		}
+	}
+}
+
+func TestGenerateStandaloneClient(t *testing.T) {
+	dir, err := ioutil.TempDir("", "meshrpc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	clientOut := filepath.Join(dir, "greeter-client")
+	files := generateGreeter(t, &Options{
+		ClientOut: clientOut,
+	})
+	client, ok := files[filepath.Join(clientOut, "client_gen.go")]
+	if !ok {
+		t.Fatalf("no standalone client among %d files", len(files))
+	}
+	checkContains(t, "client", client,
+		"package greeterclient",
+		"type Service interface",
+		"type Postcard struct",
+		"Recipient  string `validate:\"required,max=64\"`",
+		"func NewServiceClient(",
+	)
+	if strings.Contains(client, "example/greeter/service\"") {
+		t.Error("standalone client imports the service package")
+	}
+	if len(files) != 2 {
+		t.Errorf("got %d files, want the handler and the client", len(files))
+	}
+}
+
+func TestGenerateNetHTTPHandler(t *testing.T) {
+	files := generateGreeter(t, &Options{
+		Server: ServerNetHTTP,
+	})
+	var handler string
+	for path, contents := range files {
+		if filepath.Base(path) == "handler_gen.go" {
+			handler = contents
+		}
+	}
+	checkContains(t, "handler", handler,
+		"Greet(http.ResponseWriter, *http.Request)",
+		"func (_handler *rpcHandler) Greet(_w http.ResponseWriter, _r *http.Request) {",
+		"BatchHandler() http.Handler",
+	)
+	if strings.Contains(handler, "\"github.com/astranet/httpserve\"") {
+		t.Error("net/http handler imports httpserve")
+	}
+}
+
+func TestLoadTemplates(t *testing.T) {
+	dir, err := ioutil.TempDir("", "meshrpc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	if _, err := LoadTemplates(dir); err == nil {
+		t.Error("LoadTemplates() of a dir without templates succeeded")
+	}
+
+	overrides := map[string]string{
+		rpcClientTemplate: "package {{.PackageName}}\n\n{{template \"banner.tpl\" .}}\n",
+		"banner.tpl":      "// {{len .Methods}} methods of {{.ServiceName}}",
+	}
+	for name, contents := range overrides {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(contents), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	tpls, err := LoadTemplates(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tpls.Lookup(rpcHandlerTemplate) == nil {
+		t.Errorf("embedded %s is missing", rpcHandlerTemplate)
+	}
+	files := generateGreeter(t, &Options{
+		Templates: tpls,
+	})
+	for path, contents := range files {
+		if filepath.Base(path) != "client_gen.go" {
+			continue
+		}
+		if want := "package greeter\n\n// 2 methods of greeter.Service\n"; contents != want {
+			t.Errorf("client = %q, want %q", contents, want)
+		}
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	srcDir, err := filepath.Abs(filepath.Join("..", "example", "greeter", "service"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := Generate(srcDir, &Options{
+		PackageName: "greeter",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var paths []string
+	for _, f := range res.Files {
+		paths = append(paths, f.Path)
+	}
+	want := []string{
+		filepath.Join(srcDir, "handler_gen.go"),
+		filepath.Join(srcDir, "client_gen.go"),
+	}
+	if !reflect.DeepEqual(paths, want) {
+		t.Errorf("Generate() paths = %v, want %v", paths, want)
+	}
+	if n := len(res.Interface.Methods); n != 2 {
+		t.Errorf("Generate() interface has %d methods, want 2", n)
+	}
+	fingerprint := `RPCHandlerFingerprint = "` + res.Schema.Fingerprint() + `"`
+	checkContains(t, "handler", string(res.Files[0].Contents), fingerprint)
+
+	tests := []struct {
+		name string
+		opt  *Options
+		err  string
+	}{{
+		name: "unknown server",
+		opt:  &Options{PackageName: "greeter", Server: "grpc"},
+		err:  `unknown server kind "grpc"`,
+	}, {
+		name: "unknown feature prefix",
+		opt:  &Options{PackageName: "greeter", FeaturePrefix: "Shop"},
+		err:  "failed to locate greeter.ShopService interface",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Generate(srcDir, tt.opt)
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("Generate() error = %v, want %q", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestOneway(t *testing.T) {
+	iface, srcDir := loadTestService(t, "docsvc")
+	if err := checkOneway(iface); err != nil {
+		t.Fatal(err)
+	}
+	tpls, err := LoadTemplates("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := &TemplateContext{
+		PackageName:           "docsvc",
+		ServiceName:           "docsvc.Service",
+		RPCHandlerPrivateName: rpcHandlerPrivateName(""),
+		RPCClientPrivateName:  rpcClientPrivateName(""),
+	}
+	for i := range iface.Methods {
+		ctx.Methods = append(ctx.Methods, &iface.Methods[i])
+	}
+	if !ctx.HasOneway() {
+		t.Fatal("HasOneway() = false, want true")
+	}
+	for _, name := range []string{rpcHandlerTemplate, netHTTPHandlerTemplate, rpcClientTemplate} {
+		contents, err := ctx.Render(tpls, name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		f := File{Path: filepath.Join(srcDir, name+".go"), Contents: contents}
+		if _, err := f.Format(); err != nil {
+			t.Fatalf("failed to format %s: %v\n%s", name, err, contents)
+		}
+		if name == rpcClientTemplate {
+			checkContains(t, name, string(contents), "// Drop is oneway")
+			continue
+		}
+		checkContains(t, name, string(contents),
+			"OnewayWorkers int",
+			`return _handler.goOneway(ctx, "Drop", func(ctx context.Context) {`,
+			"return &_resp, 202, nil",
+			"rpcHandlerErrOnewayQueueFull",
+		)
+	}
+
+	iface, err = NewMethodsCollection("github.com/astranet/meshRPC/generator/testdata/docsvc.OnewayService", srcDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := checkOneway(iface); err == nil || !strings.Contains(err.Error(), "Notify is marked oneway, but has results") {
+		t.Errorf("checkOneway() = %v, want an error of Notify", err)
+	}
+}
+
+func TestValidateRules(t *testing.T) {
+	iface, srcDir := loadTestService(t, "docsvc")
+	if err := checkValidateRules(iface); err != nil {
+		t.Fatal(err)
+	}
+	iface, err := NewMethodsCollection("github.com/astranet/meshRPC/generator/testdata/docsvc.RulesService", srcDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = checkValidateRules(iface)
+	if err == nil || !strings.Contains(err.Error(), filepath.Join(srcDir, "service.go")+":36:") ||
+		!strings.Contains(err.Error(), "Rename param name: unknown rule maxlen") {
+		t.Errorf("checkValidateRules() = %v, want an unknown rule of Rename at service.go:36", err)
+	}
+}
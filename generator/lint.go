@@ -0,0 +1,250 @@
+package generator
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// LintCheck identifies a class of wire-compatibility problems.
+type LintCheck string
+
+const (
+	// LintChan reports channels that cannot be encoded into JSON.
+	LintChan LintCheck = "chan"
+	// LintFunc reports funcs that cannot be encoded into JSON.
+	LintFunc LintCheck = "func"
+	// LintIface reports interface-typed values that JSON cannot decode into a concrete type.
+	LintIface LintCheck = "iface"
+	// LintUnexported reports unexported struct fields that are silently dropped by JSON.
+	LintUnexported LintCheck = "unexported"
+	// LintMapKey reports map keys that JSON cannot encode.
+	LintMapKey LintCheck = "map-key"
+	// LintTime reports time.Time values that lose precision over the wire.
+	LintTime LintCheck = "time"
+	// LintUnnamed reports unnamed params, which are not transmitted at all.
+	LintUnnamed LintCheck = "unnamed"
+)
+
+// LintIssue is a single problem found by the linter.
+type LintIssue struct {
+	Pos     token.Position
+	Check   LintCheck
+	Where   string
+	Message string
+}
+
+func (l LintIssue) String() string {
+	return fmt.Sprintf("%s: [%s] %s: %s", l.Pos, l.Check, l.Where, l.Message)
+}
+
+// LintMethods checks all methods of the service interface for types that
+// meshRPC can't carry faithfully over JSON.
+func LintMethods(iface *MethodsCollection, srcDir string) ([]LintIssue, error) {
+	l := &linter{
+		resolver: newTypeResolver(srcDir),
+		visited:  make(map[string]bool),
+	}
+	err := iface.ForEachMethod(func(m *Method) error {
+		scope, err := l.resolver.methodScope(m)
+		if err != nil {
+			return err
+		}
+		pos := m.src.Pkg.Position(m.src.Field.Pos())
+		for _, p := range m.Params {
+			where := fmt.Sprintf("%s(%s)", m.Name, p.Name)
+			if len(p.Name) == 0 {
+				where = fmt.Sprintf("%s(%s)", m.Name, p.Type)
+				l.report(pos, LintUnnamed, where, "unnamed param is not transmitted, the handler will get a zero value")
+			}
+			l.checkExpr(scope, pos, where, p.expr)
+		}
+		for i, r := range m.Res {
+			if r.Type == "error" {
+				// errors are handled by the generated code
+				continue
+			}
+			where := fmt.Sprintf("%s() %s", m.Name, r.Name)
+			if len(r.Name) == 0 {
+				where = fmt.Sprintf("%s() _ret%d", m.Name, i)
+			}
+			l.checkExpr(scope, pos, where, r.expr)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(l.issues, func(i, j int) bool {
+		if l.issues[i].Pos.Filename != l.issues[j].Pos.Filename {
+			return l.issues[i].Pos.Filename < l.issues[j].Pos.Filename
+		}
+		return l.issues[i].Pos.Line < l.issues[j].Pos.Line
+	})
+	return l.issues, nil
+}
+
+type linter struct {
+	resolver *typeResolver
+	visited  map[string]bool
+	issues   []LintIssue
+}
+
+func (l *linter) report(pos token.Position, check LintCheck, where, msg string) {
+	l.issues = append(l.issues, LintIssue{
+		Pos:     pos,
+		Check:   check,
+		Where:   where,
+		Message: msg,
+	})
+}
+
+func (l *linter) checkExpr(scope *typeScope, pos token.Position, where string, expr ast.Expr) {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		switch e.Name {
+		case "error":
+			l.report(pos, LintIface, where, "error value will be encoded as an empty object")
+			return
+		case "any":
+			l.report(pos, LintIface, where, "empty interface will be decoded as a generic map or slice")
+			return
+		}
+		l.checkNamed(scope, pos, where, e)
+	case *ast.SelectorExpr:
+		l.checkNamed(scope, pos, where, e)
+	case *ast.StarExpr:
+		l.checkExpr(scope, pos, where, e.X)
+	case *ast.ParenExpr:
+		l.checkExpr(scope, pos, where, e.X)
+	case *ast.Ellipsis:
+		l.checkExpr(scope, pos, where, e.Elt)
+	case *ast.ArrayType:
+		l.checkExpr(scope, pos, where, e.Elt)
+	case *ast.MapType:
+		if !l.isValidMapKey(scope, e.Key) {
+			l.report(pos, LintMapKey, where, fmt.Sprintf("map key type %s cannot be encoded into JSON", scope.Pkg.fmt(e.Key)))
+		}
+		l.checkExpr(scope, pos, where, e.Value)
+	case *ast.ChanType:
+		l.report(pos, LintChan, where, "channels cannot be encoded into JSON")
+	case *ast.FuncType:
+		l.report(pos, LintFunc, where, "funcs cannot be encoded into JSON")
+	case *ast.InterfaceType:
+		if e.Methods == nil || len(e.Methods.List) == 0 {
+			l.report(pos, LintIface, where, "empty interface will be decoded as a generic map or slice")
+			return
+		}
+		l.report(pos, LintIface, where, "interface value has no concrete type to be decoded into")
+	case *ast.StructType:
+		l.checkStruct(scope, where, e)
+	}
+}
+
+func (l *linter) checkNamed(scope *typeScope, pos token.Position, where string, expr ast.Expr) {
+	decl, err := l.resolver.lookup(scope, expr)
+	if err != nil || decl == nil {
+		// predeclared or unresolvable types are left as is
+		return
+	}
+	name := decl.FullName()
+	if name == "time.Time" {
+		l.report(pos, LintTime, where, "time.Time is encoded as RFC 3339 text, monotonic clock reading and location are lost")
+		return
+	}
+	if decl.HasMethod("MarshalJSON") || decl.HasMethod("UnmarshalJSON") {
+		// custom codec is responsible for the wire format
+		return
+	}
+	if _, ok := decl.Spec.Type.(*ast.InterfaceType); ok {
+		// named interfaces are reported for each usage
+		l.checkExpr(decl.Scope(), pos, where, decl.Spec.Type)
+		return
+	}
+	if l.visited[name] {
+		return
+	}
+	l.visited[name] = true
+	switch typ := decl.Spec.Type.(type) {
+	case *ast.StructType:
+		l.checkStruct(decl.Scope(), decl.Spec.Name.Name, typ)
+	default:
+		l.checkExpr(decl.Scope(), decl.Pkg.Position(decl.Spec.Pos()), decl.Spec.Name.Name, typ)
+	}
+}
+
+func (l *linter) checkStruct(scope *typeScope, where string, typ *ast.StructType) {
+	if typ.Fields == nil {
+		return
+	}
+	for _, field := range typ.Fields.List {
+		pos := scope.Pkg.Position(field.Pos())
+		tag := jsonTagName(field)
+		if tag == "-" {
+			continue
+		}
+		if len(field.Names) == 0 {
+			// embedded fields are promoted by JSON
+			l.checkExpr(scope, pos, where, field.Type)
+			continue
+		}
+		for _, name := range field.Names {
+			fieldWhere := where + "." + name.Name
+			if !name.IsExported() {
+				l.report(pos, LintUnexported, fieldWhere, "unexported field is dropped by JSON")
+				continue
+			}
+			l.checkExpr(scope, pos, fieldWhere, field.Type)
+		}
+	}
+}
+
+// isValidMapKey reports whether JSON is able to encode the map key type,
+// i.e. it's a string, an integer or implements encoding.TextMarshaler.
+func (l *linter) isValidMapKey(scope *typeScope, expr ast.Expr) bool {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		switch e.Name {
+		case "string",
+			"int", "int8", "int16", "int32", "int64", "rune",
+			"uint", "uint8", "uint16", "uint32", "uint64", "uintptr", "byte":
+			return true
+		}
+		if isPredeclared(e.Name) {
+			return false
+		}
+	case *ast.SelectorExpr:
+	case *ast.ParenExpr:
+		return l.isValidMapKey(scope, e.X)
+	default:
+		return false
+	}
+	decl, err := l.resolver.lookup(scope, expr)
+	if err != nil || decl == nil {
+		return true
+	}
+	if decl.HasMethod("MarshalText") {
+		return true
+	}
+	return l.isValidMapKey(decl.Scope(), decl.Spec.Type)
+}
+
+// jsonTagName returns the name part of the json tag of a struct field.
+func jsonTagName(field *ast.Field) string {
+	if field.Tag == nil {
+		return ""
+	}
+	raw, err := strconv.Unquote(field.Tag.Value)
+	if err != nil {
+		return ""
+	}
+	name := reflect.StructTag(raw).Get("json")
+	if idx := strings.Index(name, ","); idx >= 0 {
+		name = name[:idx]
+	}
+	return name
+}
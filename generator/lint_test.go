@@ -0,0 +1,49 @@
+package generator
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// loadTestService loads the Service interface of a package in testdata.
+func loadTestService(t *testing.T, pkg string) (*MethodsCollection, string) {
+	t.Helper()
+	srcDir, err := filepath.Abs(filepath.Join("testdata", pkg))
+	if err != nil {
+		t.Fatal(err)
+	}
+	iface, err := NewMethodsCollection("github.com/astranet/meshRPC/generator/testdata/"+pkg+".Service", srcDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return iface, srcDir
+}
+
+func TestLintMethods(t *testing.T) {
+	iface, srcDir := loadTestService(t, "lintsvc")
+	issues, err := LintMethods(iface, srcDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, issue := range issues {
+		got = append(got, string(issue.Check)+" "+issue.Where)
+	}
+	want := []string{
+		"chan Stream(ch)",
+		"func Stream(fn)",
+		"iface Opaque(v)",
+		"iface Opaque(r)",
+		"iface Opaque() any",
+		"map-key Keys(m)",
+		"time Times(at)",
+		"unnamed Unnamed(int)",
+		"unnamed Unnamed(string)",
+		"time Meta.Created",
+		"unexported Meta.secret",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("LintMethods() =\n%q\nwant\n%q", got, want)
+	}
+}
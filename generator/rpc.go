@@ -0,0 +1,218 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Methods of Method and Param below are helpers for templates, they keep Go
+// naming rules of the generated code in one place.
+
+// Signature returns the method spec as declared in the service interface,
+// e.g. "Greet(name string) (message string, err error)".
+func (m *Method) Signature() string {
+	return funcSpec(m, false)
+}
+
+// ClientSignature returns the method spec with all results named, so the
+// client implementation could assign them, e.g. "Greet(name string) (message string, _err error)".
+func (m *Method) ClientSignature() string {
+	return funcSpec(m, true)
+}
+
+// ContextSignature returns the spec of the context-aware client method variant, e.g.
+// "GreetContext(_ctx context.Context, name string, _opts ...CallOption) (message string, _err error)".
+// Variadic params are passed as slices, since call options go last.
+func (m *Method) ContextSignature(optionType string) string {
+	return fmt.Sprintf("%sContext(%s) %s", m.Name, contextParams(m, optionType), resultSpec(m, true))
+}
+
+// AsyncSignature returns the spec of the asynchronous client method variant, e.g.
+// "GreetAsync(_ctx context.Context, name string, _opts ...CallOption) *GreetFuture".
+func (m *Method) AsyncSignature(optionType string) string {
+	return fmt.Sprintf("%sAsync(%s) *%sFuture", m.Name, contextParams(m, optionType), m.Name)
+}
+
+// BatchSignature returns the spec of the batch builder method, e.g.
+// "Greet(name string) *GreetFuture".
+func (m *Method) BatchSignature() string {
+	params := paramSpecs(m, true)
+	return fmt.Sprintf("%s(%s) *%sFuture", m.Name, strings.Join(params, ", "), m.Name)
+}
+
+// OutboxSignature returns the spec of the outbox builder method, e.g.
+// "SendPostcard(card *Postcard) error".
+func (m *Method) OutboxSignature() string {
+	params := paramSpecs(m, true)
+	return fmt.Sprintf("%s(%s) error", m.Name, strings.Join(params, ", "))
+}
+
+// Queueable reports whether calls of the method may be queued in an outbox, that's
+// the case for methods that return no results apart from an error, as there is
+// nobody to return results to once the call is delivered.
+func (m *Method) Queueable() bool {
+	for _, r := range m.Res {
+		if !r.IsError() {
+			return false
+		}
+	}
+	return true
+}
+
+// ClientResults returns the results spec of the ClientSignature, e.g. "(message string, _err error)".
+func (m *Method) ClientResults() string {
+	return resultSpec(m, true)
+}
+
+// CallArgs returns names of params in the ClientSignature, so a client method
+// could pass them to another one.
+func (m *Method) CallArgs() []string {
+	args := make([]string, 0, len(m.Params))
+	for _, p := range m.Params {
+		args = append(args, p.ArgName())
+	}
+	return args
+}
+
+// HasError reports whether the method returns an error.
+func (m *Method) HasError() bool {
+	return hasErr(m.Res)
+}
+
+// IsError reports whether the param is an error, errors are not carried in response models.
+func (p Param) IsError() bool {
+	return p.Type == "error"
+}
+
+// FieldName returns the name of the request or response model field for the param.
+func (p Param) FieldName() string {
+	if len(p.Name) == 0 && p.result {
+		return fmt.Sprintf("Ret%d", p.index)
+	}
+	return strings.Title(p.Name)
+}
+
+// JSONKey returns the key of the param on the wire.
+func (p Param) JSONKey() string {
+	if len(p.Name) == 0 && p.result {
+		return fmt.Sprintf("_ret%d", p.index)
+	}
+	return p.Name
+}
+
+// ArgName returns the name of the param in the ClientSignature.
+func (p Param) ArgName() string {
+	if len(p.Name) == 0 {
+		return fmt.Sprintf("_arg%d", p.index)
+	}
+	return p.Name
+}
+
+// VarName returns the name of the result variable in the ClientSignature.
+func (p Param) VarName() string {
+	switch {
+	case p.IsError():
+		return "_err"
+	case len(p.Name) == 0:
+		return fmt.Sprintf("_ret%d", p.index)
+	default:
+		return p.Name
+	}
+}
+
+func funcSpec(m *Method, forceNaming bool) string {
+	params := paramSpecs(m, forceNaming)
+	if m.Context {
+		ctxName := m.ctxName
+		if forceNaming {
+			ctxName = "_ctx"
+		}
+		params = append([]string{ctxName + " context.Context"}, params...)
+	}
+	return fmt.Sprintf("%s(%s) %s", m.Name, strings.Join(params, ", "), resultSpec(m, forceNaming))
+}
+
+func contextParams(m *Method, optionType string) string {
+	params := []string{"_ctx context.Context"}
+	for _, p := range paramSpecs(m, true) {
+		params = append(params, strings.Replace(p, " ...", " []", 1))
+	}
+	params = append(params, "_opts ..."+optionType)
+	return strings.Join(params, ", ")
+}
+
+// paramSpecs returns specs of the method params, unnamed params are named
+// after their position if forceNaming is set.
+func paramSpecs(m *Method, forceNaming bool) []string {
+	params := make([]string, 0, len(m.Params))
+	for _, p := range m.Params {
+		if forceNaming {
+			params = append(params, p.ArgName()+" "+p.Type)
+			continue
+		}
+		params = append(params, p.Name+" "+p.Type)
+	}
+	return params
+}
+
+func resultSpec(m *Method, forceNaming bool) string {
+	var retSpec string
+	switch {
+	case len(m.Res) == 0:
+	case len(m.Res) == 1:
+		ret := m.Res[0]
+		if len(ret.Name) == 0 {
+			if forceNaming {
+				if ret.Type == "error" {
+					retSpec = "(_err error)"
+				} else {
+					retSpec = fmt.Sprintf("(_ret0 %s)", ret.Type)
+				}
+			} else {
+				retSpec = ret.Type
+			}
+		} else {
+			if ret.Type == "error" && forceNaming {
+				retSpec = fmt.Sprintf("(_err %s)", ret.Type)
+			} else {
+				retSpec = fmt.Sprintf("(%s %s)", ret.Name, ret.Type)
+			}
+		}
+	default:
+		rets := make([]string, 0, len(m.Res))
+		if len(m.Res[0].Name) == 0 {
+			if forceNaming {
+				for i, r := range m.Res {
+					if r.Type == "error" {
+						rets = append(rets, fmt.Sprintf("_err %s", r.Type))
+					} else {
+						rets = append(rets, fmt.Sprintf("_ret%d %s", i, r.Type))
+					}
+				}
+			} else {
+				for _, r := range m.Res {
+					rets = append(rets, r.Type)
+				}
+			}
+		} else {
+			for _, r := range m.Res {
+				if r.Type == "error" && forceNaming {
+					rets = append(rets, "_err "+r.Type)
+				} else {
+					rets = append(rets, r.Name+" "+r.Type)
+				}
+			}
+		}
+		retSpec = fmt.Sprintf("(%s)", strings.Join(rets, ", "))
+	}
+	return retSpec
+}
+
+func hasErr(rets []Param) bool {
+	for i := range rets {
+		if rets[i].Type == "error" {
+			return true
+		}
+	}
+	return false
+}
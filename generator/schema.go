@@ -0,0 +1,280 @@
+package generator
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"go/ast"
+	"sort"
+	"strings"
+)
+
+// Schema describes the wire format of a service interface: methods with their
+// JSON keys and all struct types reachable from params and results.
+type Schema struct {
+	Methods []MethodSchema
+	Types   map[string]*TypeSchema
+
+	srcFiles map[string]bool
+}
+
+// MethodSchema describes request and response models of a method.
+type MethodSchema struct {
+	Name   string
+	Params []FieldSchema
+	Res    []FieldSchema
+}
+
+// TypeSchema describes a named type that is not a builtin or a stdlib type.
+type TypeSchema struct {
+	Name string
+	// Underlying is set for non-struct types, e.g. "[]string".
+	Underlying string
+	// Fields are set for struct types only.
+	Fields []FieldSchema
+	// Custom is true when the type has own JSON codec.
+	Custom bool
+}
+
+// FieldSchema describes a single value of the wire format.
+type FieldSchema struct {
+	// Key is the JSON key of the value.
+	Key string
+	// Name is the Go name of the value.
+	Name string
+	// Type is the canonical type, fully qualified with import paths.
+	Type string
+}
+
+// Method returns a method schema by its name.
+func (s *Schema) Method(name string) (MethodSchema, bool) {
+	for _, m := range s.Methods {
+		if m.Name == name {
+			return m, true
+		}
+	}
+	return MethodSchema{}, false
+}
+
+// String returns the canonical text form of the schema,
+// it's stable across runs and suitable for hashing.
+func (s *Schema) String() string {
+	buf := new(bytes.Buffer)
+	for _, m := range s.Methods {
+		fmt.Fprintf(buf, "method %s\n", m.Name)
+		for _, p := range m.Params {
+			fmt.Fprintf(buf, "\tparam %s %s\n", p.Key, p.Type)
+		}
+		for _, r := range m.Res {
+			fmt.Fprintf(buf, "\tresult %s %s\n", r.Key, r.Type)
+		}
+	}
+	names := make([]string, 0, len(s.Types))
+	for name := range s.Types {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		t := s.Types[name]
+		switch {
+		case t.Custom:
+			fmt.Fprintf(buf, "type %s custom\n", name)
+		case len(t.Underlying) > 0:
+			fmt.Fprintf(buf, "type %s %s\n", name, t.Underlying)
+		default:
+			fmt.Fprintf(buf, "type %s struct\n", name)
+			for _, f := range t.Fields {
+				fmt.Fprintf(buf, "\tfield %s %s\n", f.Key, f.Type)
+			}
+		}
+	}
+	return buf.String()
+}
+
+// SrcFiles returns source files that declare the service interface methods
+// and all the types reachable from them, except stdlib ones.
+func (s *Schema) SrcFiles() []string {
+	files := make([]string, 0, len(s.srcFiles))
+	for file := range s.srcFiles {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+	return files
+}
+
+// Fingerprint returns a short hash of the canonical schema form, clients and handlers
+// generated from interfaces with the same wire format share the same fingerprint.
+func (s *Schema) Fingerprint() string {
+	sum := sha256.Sum256([]byte(s.String()))
+	return hex.EncodeToString(sum[:8])
+}
+
+// NewSchema builds the wire format schema of the service interface.
+func NewSchema(iface *MethodsCollection, srcDir string) (*Schema, error) {
+	b := &schemaBuilder{
+		resolver: newTypeResolver(srcDir),
+		schema: &Schema{
+			Types:    make(map[string]*TypeSchema),
+			srcFiles: make(map[string]bool),
+		},
+	}
+	err := iface.ForEachMethod(func(m *Method) error {
+		scope, err := b.resolver.methodScope(m)
+		if err != nil {
+			return err
+		}
+		b.schema.srcFiles[m.src.Pkg.Position(m.src.Field.Pos()).Filename] = true
+		ms := MethodSchema{
+			Name: m.Name,
+		}
+		for _, p := range m.Params {
+			if len(p.Name) == 0 {
+				// not transmitted
+				continue
+			}
+			ms.Params = append(ms.Params, FieldSchema{
+				Key:  p.Name,
+				Name: p.Name,
+				Type: b.canonicalType(scope, p.expr),
+			})
+		}
+		for i, r := range m.Res {
+			if r.Type == "error" {
+				continue
+			}
+			f := FieldSchema{
+				Key:  r.Name,
+				Name: r.Name,
+				Type: b.canonicalType(scope, r.expr),
+			}
+			if len(r.Name) == 0 {
+				f.Key = fmt.Sprintf("_ret%d", i)
+			}
+			ms.Res = append(ms.Res, f)
+		}
+		b.schema.Methods = append(b.schema.Methods, ms)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(b.schema.Methods, func(i, j int) bool {
+		return b.schema.Methods[i].Name < b.schema.Methods[j].Name
+	})
+	return b.schema, nil
+}
+
+type schemaBuilder struct {
+	resolver *typeResolver
+	schema   *Schema
+}
+
+// canonicalType returns the type expression with all named types qualified by
+// their import paths, it also collects reachable named types into the schema.
+func (b *schemaBuilder) canonicalType(scope *typeScope, expr ast.Expr) string {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		if isPredeclared(e.Name) {
+			return e.Name
+		}
+		return b.namedType(scope, e)
+	case *ast.SelectorExpr:
+		return b.namedType(scope, e)
+	case *ast.StarExpr:
+		return "*" + b.canonicalType(scope, e.X)
+	case *ast.ParenExpr:
+		return b.canonicalType(scope, e.X)
+	case *ast.Ellipsis:
+		return "..." + b.canonicalType(scope, e.Elt)
+	case *ast.ArrayType:
+		if e.Len == nil {
+			return "[]" + b.canonicalType(scope, e.Elt)
+		}
+		return fmt.Sprintf("[%s]%s", scope.Pkg.fmt(e.Len), b.canonicalType(scope, e.Elt))
+	case *ast.MapType:
+		return fmt.Sprintf("map[%s]%s", b.canonicalType(scope, e.Key), b.canonicalType(scope, e.Value))
+	case *ast.StructType:
+		fields := b.structFields(scope, e)
+		parts := make([]string, 0, len(fields))
+		for _, f := range fields {
+			parts = append(parts, f.Key+" "+f.Type)
+		}
+		return "struct{" + strings.Join(parts, "; ") + "}"
+	default:
+		// chans, funcs and interfaces are not carried over the wire anyway
+		return scope.Pkg.fmt(expr)
+	}
+}
+
+func (b *schemaBuilder) namedType(scope *typeScope, expr ast.Expr) string {
+	decl, err := b.resolver.lookup(scope, expr)
+	if err != nil || decl == nil {
+		return scope.Pkg.fmt(expr)
+	}
+	name := decl.FullName()
+	if decl.Pkg.Goroot {
+		// stdlib types are stable
+		return name
+	}
+	if _, ok := b.schema.Types[name]; ok {
+		return name
+	}
+	t := &TypeSchema{
+		Name: name,
+	}
+	b.schema.Types[name] = t
+	b.schema.srcFiles[decl.Pkg.Position(decl.Spec.Pos()).Filename] = true
+	if decl.HasMethod("MarshalJSON") || decl.HasMethod("UnmarshalJSON") {
+		t.Custom = true
+		return name
+	}
+	if st, ok := decl.Spec.Type.(*ast.StructType); ok {
+		t.Fields = b.structFields(decl.Scope(), st)
+	} else {
+		t.Underlying = b.canonicalType(decl.Scope(), decl.Spec.Type)
+	}
+	return name
+}
+
+func (b *schemaBuilder) structFields(scope *typeScope, st *ast.StructType) []FieldSchema {
+	if st.Fields == nil {
+		return nil
+	}
+	var fields []FieldSchema
+	for _, field := range st.Fields.List {
+		tag := jsonTagName(field)
+		if tag == "-" {
+			continue
+		}
+		typ := b.canonicalType(scope, field.Type)
+		if len(field.Names) == 0 {
+			// embedded field, untagged ones are keyed by their type
+			key := tag
+			if len(key) == 0 {
+				key = typ
+			}
+			fields = append(fields, FieldSchema{
+				Key:  key,
+				Name: strings.TrimPrefix(typ, "*"),
+				Type: typ,
+			})
+			continue
+		}
+		for _, name := range field.Names {
+			if !name.IsExported() {
+				continue
+			}
+			key := tag
+			if len(key) == 0 {
+				key = name.Name
+			}
+			fields = append(fields, FieldSchema{
+				Key:  key,
+				Name: name.Name,
+				Type: typ,
+			})
+		}
+	}
+	return fields
+}
@@ -0,0 +1,64 @@
+package generator
+
+import (
+	"testing"
+)
+
+func TestNewSchema(t *testing.T) {
+	iface, srcDir := loadTestService(t, "schemasvc")
+	schema, err := NewSchema(iface, srcDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	const pkg = "github.com/astranet/meshRPC/generator/testdata/schemasvc"
+	want := `method Get
+	param id ` + pkg + `.ID
+	result _ret0 *` + pkg + `.Item
+	result _ret1 bool
+method Put
+	param item *` + pkg + `.Item
+	param tags ` + pkg + `.Tags
+	result id ` + pkg + `.ID
+type ` + pkg + `.ID int64
+type ` + pkg + `.Item struct
+	field name string
+	field price ` + pkg + `.Price
+	field Created time.Time
+	field ` + pkg + `.Meta ` + pkg + `.Meta
+type ` + pkg + `.Meta struct
+	field Labels map[string]string
+type ` + pkg + `.Price custom
+type ` + pkg + `.Tags []string
+`
+	if got := schema.String(); got != want {
+		t.Errorf("Schema.String() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestSchemaFingerprint(t *testing.T) {
+	schema := func(key, name, typ string) *Schema {
+		return &Schema{
+			Methods: []MethodSchema{{
+				Name:   "Add",
+				Params: []FieldSchema{{Key: key, Name: name, Type: typ}},
+			}},
+		}
+	}
+	base := schema("n", "n", "int").Fingerprint()
+	if len(base) != 16 {
+		t.Errorf("Fingerprint() = %q, want 16 hex digits", base)
+	}
+	if fp := schema("n", "n", "int").Fingerprint(); fp != base {
+		t.Errorf("Fingerprint() of the same schema = %s, want %s", fp, base)
+	}
+	// Go names are not a part of the wire format
+	if fp := schema("n", "N", "int").Fingerprint(); fp != base {
+		t.Errorf("Fingerprint() with a renamed Go name = %s, want %s", fp, base)
+	}
+	if fp := schema("m", "n", "int").Fingerprint(); fp == base {
+		t.Error("Fingerprint() with a changed JSON key is the same")
+	}
+	if fp := schema("n", "n", "string").Fingerprint(); fp == base {
+		t.Error("Fingerprint() with a changed type is the same")
+	}
+}
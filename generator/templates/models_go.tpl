@@ -0,0 +1,383 @@
+{{/* Partials shared by handler and client templates, the dot is a *Method unless noted. */}}
+
+{{define "request_model"}}
+// {{.Name}}Request holds params of {{.Name}}.
+{{- with .Doc}}
+//
+{{comment .}}
+{{- end}}
+type {{.Name}}Request struct {
+{{- range .Params}}{{if .Name}}
+{{- with .Doc}}
+	{{comment .}}
+{{- end}}
+	{{.FieldName}} {{.Type}} `json:"{{.JSONKey}},omitempty"{{with .Validate}} validate:"{{.}}"{{end}}`
+{{- end}}{{end}}
+}
+{{end}}
+
+{{define "response_model"}}
+// {{.Name}}Response holds results of {{.Name}}.
+{{- with .Doc}}
+//
+{{comment .}}
+{{- end}}
+type {{.Name}}Response struct {
+{{- range .Res}}{{if not .IsError}}
+{{- with .Doc}}
+	{{comment .}}
+{{- end}}
+	{{.FieldName}} {{.Type}} `json:"{{.JSONKey}},omitempty"`
+{{- end}}{{end}}
+}
+{{end}}
+
+{{define "method_docs"}}
+{{- /* the dot is a *TemplateContext */ -}}
+var {{.RPCHandlerPrivateName}}MethodDocs = map[string]string{
+{{- range .Methods}}{{if .Doc}}
+	"{{.Name}}": {{printf "%q" .Doc}},
+{{- end}}{{end}}
+}
+
+// MethodDocs returns doc comments of the service interface methods, by method name.
+func (_ *{{.RPCHandlerPrivateName}}) MethodDocs() map[string]string {
+	return {{.RPCHandlerPrivateName}}MethodDocs
+}
+{{- end}}
+
+{{define "service_call"}}
+	{{- if .Res}}{{range $i, $r := .Res}}{{if $i}}, {{end}}{{if .IsError}}_err{{else}}_resp.{{.FieldName}}{{end}}{{end}} = {{end -}}
+	_handler.svc.{{.Name}}({{if .Context}}ctx{{if .Params}}, {{end}}{{end}}{{range $i, $p := .Params}}{{if $i}}, {{end}}{{if .Name}}_req.{{.FieldName}}{{else}}nil{{end}}{{end}})
+{{- end}}
+
+{{define "service_invoke"}}
+{{- /* the func that validates the request and calls the service, see invoke */ -}}
+func(ctx context.Context) (_err error) {
+		if _err = validate.Struct(&_req); _err != nil {
+			return
+		}
+		{{- if .Oneway}}
+		return _handler.goOneway(ctx, "{{.Name}}", func(ctx context.Context) {
+			{{template "service_call" .}}
+		})
+		{{- else}}
+		{{template "service_call" .}}
+		return
+		{{- end}}
+	}
+{{- end}}
+
+{{define "invoke"}}
+{{- /* the dot is a *TemplateContext */ -}}
+// invoke starts a span of the call of the service method, continuing the trace of the
+// caller, and passes the call through interceptors of the handler options, then makes it
+// with fn. The context of the call expires along with the deadline of the client, and is
+// cancelled once the client goes away, then the service isn't called at all if it's not yet.
+// Calls with an idempotency key are made once, see callOnce.
+func (_handler *{{.RPCHandlerPrivateName}}) invoke(ctx context.Context, header http.Header,
+	method string, req, resp interface{}, fn func(ctx context.Context) error) (err error) {
+	ctx, cancel := {{.RPCHandlerPrivateName}}WithTimeout(ctx, header)
+	defer cancel()
+	ctx, span := trace.Start(ctx, "{{.ServiceName}}/"+method, trace.KindServer, header)
+	defer func() {
+		if err != nil {
+			status, _ := {{.RPCHandlerPrivateName}}ErrorStatus(err)
+			span.SetStatus(status)
+		}
+		span.Finish(err)
+	}()
+	if len(_handler.opt.Interceptors) == 0 {
+		return _handler.callOnce(ctx, header, method, req, resp, fn)
+	}
+	call := &intercept.Call{
+		Service:  "{{.ServiceName}}",
+		Method:   method,
+		Request:  req,
+		Response: resp,
+		Header:   header,
+		Server:   true,
+	}
+	return intercept.Invoke(ctx, _handler.opt.Interceptors, call, func(ctx context.Context, _ *intercept.Call) error {
+		return _handler.callOnce(ctx, header, method, req, resp, fn)
+	})
+}
+
+// {{.RPCHandlerPrivateName}}CallAlive calls fn unless ctx is done, e.g. the client has gone away.
+func {{.RPCHandlerPrivateName}}CallAlive(ctx context.Context, fn func(ctx context.Context) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return fn(ctx)
+}
+
+const (
+	// {{.RPCHandlerPrivateName}}IdempotencyKeys is the default number of idempotency keys a handler keeps.
+	{{.RPCHandlerPrivateName}}IdempotencyKeys = 1024
+	// {{.RPCHandlerPrivateName}}IdempotencyTTL is the default time a handler keeps an idempotency key for.
+	{{.RPCHandlerPrivateName}}IdempotencyTTL = 10 * time.Minute
+)
+
+// callOnce makes the call with fn, unless it carries the idempotency key of a call to the method
+// that has been made already, then the response of that call is replayed into resp. The cache of
+// keys is created along with the first call that carries one. Calls are deduplicated inside
+// interceptors, so that replays are authorized the same way as calls.
+func (_handler *{{.RPCHandlerPrivateName}}) callOnce(ctx context.Context, header http.Header,
+	method string, req, resp interface{}, fn func(ctx context.Context) error) error {
+	key := header.Get(dedup.KeyHeader)
+	if len(key) == 0 || _handler.opt.IdempotencyKeys < 0 {
+		return {{.RPCHandlerPrivateName}}CallAlive(ctx, fn)
+	}
+	_handler.dedupOnce.Do(func() {
+		size, ttl := _handler.opt.IdempotencyKeys, _handler.opt.IdempotencyTTL
+		if size == 0 {
+			size = {{.RPCHandlerPrivateName}}IdempotencyKeys
+		}
+		if ttl <= 0 {
+			ttl = {{.RPCHandlerPrivateName}}IdempotencyTTL
+		}
+		_handler.dedup = dedup.NewCache(size, ttl)
+	})
+	return _handler.dedup.Do(ctx, method+"/"+key, req, resp, func() error {
+		return {{.RPCHandlerPrivateName}}CallAlive(ctx, fn)
+	})
+}
+
+// {{.RPCHandlerPrivateName}}WithTimeout derives the deadline of the call from the X-MeshRPC-Timeout
+// header, that is the time left until the deadline of the client, e.g. "1.5s".
+func {{.RPCHandlerPrivateName}}WithTimeout(ctx context.Context, header http.Header) (context.Context, context.CancelFunc) {
+	timeout, err := time.ParseDuration(header.Get("X-MeshRPC-Timeout"))
+	if err != nil {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+{{- end}}
+
+{{define "match_fingerprint"}}
+{{- /* the dot is a *TemplateContext */ -}}
+// matchFingerprint returns an error if the fingerprint of the client doesn't match
+// the one of the handler, unless mismatches are permitted.
+func (_handler *{{.RPCHandlerPrivateName}}) matchFingerprint(fingerprint, method string) error {
+	if len(fingerprint) == 0 || fingerprint == {{.FeaturePrefix}}RPCHandlerFingerprint {
+		return nil
+	}
+	if _handler.opt.PermissiveFingerprint {
+		atomic.AddUint64(&_handler.fingerprintMismatches, 1)
+		log.Printf("{{.RPCHandlerPrivateName}}: %s called by a client with fingerprint %s, expected %s",
+			method, fingerprint, {{.FeaturePrefix}}RPCHandlerFingerprint)
+		return nil
+	}
+	return fmt.Errorf("interface fingerprint mismatch: client %s, service %s",
+		fingerprint, {{.FeaturePrefix}}RPCHandlerFingerprint)
+}
+
+func (_handler *{{.RPCHandlerPrivateName}}) FingerprintMismatches() uint64 {
+	return atomic.LoadUint64(&_handler.fingerprintMismatches)
+}
+{{- end}}
+
+{{define "json_value"}}
+{{- /* the dot is a *TemplateContext */ -}}
+// {{.RPCHandlerPrivateName}}JSONValue is the JSON response envelope, the same as used by httpserve.
+type {{.RPCHandlerPrivateName}}JSONValue struct {
+	Data   interface{} `json:"data,omitempty"`
+	Errors []string    `json:"errors,omitempty"`
+}
+
+func {{.RPCHandlerPrivateName}}WriteJSON(w http.ResponseWriter, status int, data interface{}, err error) {
+	value := &{{.RPCHandlerPrivateName}}JSONValue{
+		Data: data,
+	}
+	if err != nil {
+		value.Errors = []string{err.Error()}
+	}
+	body, err := json.Marshal(value)
+	if err != nil {
+		log.Printf("{{.RPCHandlerPrivateName}}: failed to encode response: %v", err)
+		status = http.StatusInternalServerError
+		body, _ = json.Marshal(&{{.RPCHandlerPrivateName}}JSONValue{
+			Errors: []string{err.Error()},
+		})
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(body)
+}
+
+// {{.RPCHandlerPrivateName}}WriteError writes the error of a call, see {{.RPCHandlerPrivateName}}ErrorStatus.
+func {{.RPCHandlerPrivateName}}WriteError(w http.ResponseWriter, err error) {
+	status, data := {{.RPCHandlerPrivateName}}ErrorStatus(err)
+	{{.RPCHandlerPrivateName}}WriteJSON(w, status, data, err)
+}
+
+// {{.RPCHandlerPrivateName}}ErrorStatus returns the status to respond to the error of a call with,
+// see intercept.Status, along with the data. Field errors of validation are the data.
+func {{.RPCHandlerPrivateName}}ErrorStatus(err error) (int, interface{}) {
+	if fieldErrs, ok := err.(validate.Errors); ok {
+		return http.StatusBadRequest, fieldErrs
+	}
+	return intercept.Status(err), nil
+}
+{{- end}}
+
+{{define "batch_handler"}}
+{{- /* the dot is a *TemplateContext */ -}}
+// {{.RPCHandlerPrivateName}}MaxBatchCalls is the default limit of calls in a batch request.
+const {{.RPCHandlerPrivateName}}MaxBatchCalls = 100
+
+// {{.RPCHandlerPrivateName}}BatchCall is a single call of a batch request,
+// params are encoded as the request model of the method.
+type {{.RPCHandlerPrivateName}}BatchCall struct {
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params,omitempty"`
+}
+
+// {{.RPCHandlerPrivateName}}BatchResult is the result of a single call of a batch request,
+// it's the response envelope of the call along with the status it would be sent with.
+type {{.RPCHandlerPrivateName}}BatchResult struct {
+	Status int         `json:"status"`
+	Data   interface{} `json:"data,omitempty"`
+	Errors []string    `json:"errors,omitempty"`
+}
+
+// BatchHandler returns the handler of batch requests, each carries a list of calls,
+// possibly to different methods. Calls are served in order and get a result each,
+// so a failed call doesn't affect the others. See cluster.BatchSpec.
+func (_handler *{{.RPCHandlerPrivateName}}) BatchHandler() http.Handler {
+	return http.HandlerFunc(_handler.serveBatch)
+}
+
+func (_handler *{{.RPCHandlerPrivateName}}) serveBatch(_w http.ResponseWriter, _r *http.Request) {
+	_w.Header().Set("X-MeshRPC-Fingerprint", {{.FeaturePrefix}}RPCHandlerFingerprint)
+	_err := _handler.matchFingerprint(_r.Header.Get("X-MeshRPC-Fingerprint"), "__batch__")
+	if _err != nil {
+		{{.RPCHandlerPrivateName}}WriteJSON(_w, 412, nil, _err)
+		return
+	}
+	var _calls []{{.RPCHandlerPrivateName}}BatchCall
+	_decoder := json.NewDecoder(_r.Body)
+	defer _r.Body.Close()
+	if _err = _decoder.Decode(&_calls); _err != nil {
+		{{.RPCHandlerPrivateName}}WriteJSON(_w, 400, nil, _err)
+		return
+	}
+	_max := _handler.opt.MaxBatchCalls
+	if _max <= 0 {
+		_max = {{.RPCHandlerPrivateName}}MaxBatchCalls
+	}
+	if len(_calls) > _max {
+		_err = fmt.Errorf("too many calls in a batch: %d, max %d", len(_calls), _max)
+		{{.RPCHandlerPrivateName}}WriteJSON(_w, 400, nil, _err)
+		return
+	}
+	// calls of the batch share the deadline of the client
+	_ctx, _cancel := {{.RPCHandlerPrivateName}}WithTimeout(_r.Context(), _r.Header)
+	defer _cancel()
+	// calls of the batch are told apart by their index within the idempotency key of the batch
+	_key := _r.Header.Get(dedup.KeyHeader)
+	_results := make([]{{.RPCHandlerPrivateName}}BatchResult, len(_calls))
+	for _i, _call := range _calls {
+		_header := _r.Header
+		if len(_key) > 0 {
+			_header = make(http.Header, len(_r.Header))
+			for _k, _v := range _r.Header {
+				_header[_k] = _v
+			}
+			_header.Set(dedup.KeyHeader, _key+"/"+strconv.Itoa(_i))
+		}
+		_data, _status, _err := _handler.batchCall(_ctx, _header, _call)
+		_results[_i].Status = _status
+		_results[_i].Data = _data
+		if _err != nil {
+			_results[_i].Errors = []string{_err.Error()}
+		}
+	}
+	{{.RPCHandlerPrivateName}}WriteJSON(_w, 200, _results, nil)
+}
+
+// batchCall serves a single call of a batch request, it returns the response model
+// and the status, or the data of the error. Calls are intercepted the same way as single ones.
+func (_handler *{{.RPCHandlerPrivateName}}) batchCall(_ctx context.Context, _header http.Header,
+	_call {{.RPCHandlerPrivateName}}BatchCall) (interface{}, int, error) {
+	switch _call.Method {
+{{- range .Methods}}
+	case "{{.Name}}":
+		var _req {{.Name}}Request
+		if len(_call.Params) > 0 {
+			if _err := json.Unmarshal(_call.Params, &_req); _err != nil {
+				return nil, 400, _err
+			}
+		}
+		var _resp {{.Name}}Response
+		_err := _handler.invoke(_ctx, _header, "{{.Name}}", &_req, &_resp, {{template "service_invoke" .}})
+		if _err != nil {
+			_status, _data := {{$.RPCHandlerPrivateName}}ErrorStatus(_err)
+			return _data, _status, _err
+		}
+		return &_resp, {{if .Oneway}}202{{else}}200{{end}}, nil
+{{- end}}
+	default:
+		return nil, 404, fmt.Errorf("method %s not found", _call.Method)
+	}
+}
+{{- end}}
+
+{{define "oneway_pool"}}
+{{- /* the dot is a *TemplateContext */ -}}
+const (
+	// {{.RPCHandlerPrivateName}}OnewayWorkers is the default number of workers that serve oneway calls.
+	{{.RPCHandlerPrivateName}}OnewayWorkers = 16
+	// {{.RPCHandlerPrivateName}}OnewayQueue is the default number of oneway calls waiting for a worker.
+	{{.RPCHandlerPrivateName}}OnewayQueue = 1024
+)
+
+// {{.RPCHandlerPrivateName}}ErrOnewayQueueFull rejects oneway calls with 503 Service Unavailable.
+var {{.RPCHandlerPrivateName}}ErrOnewayQueueFull = &intercept.StatusError{
+	Status: http.StatusServiceUnavailable,
+	Err:    errors.New("oneway calls queue is full"),
+}
+
+// goOneway schedules the call of a oneway method on the worker pool, it fails
+// if the queue is full. Workers are started along with the first call. The call
+// outlives the request, so its context keeps the trace of ctx only.
+func (_handler *{{.RPCHandlerPrivateName}}) goOneway(ctx context.Context, method string, fn func(ctx context.Context)) error {
+	_handler.onewayOnce.Do(_handler.startOneway)
+	callCtx := context.Background()
+	if span := trace.FromContext(ctx); span != nil {
+		callCtx = trace.NewContext(callCtx, span)
+	}
+	call := func() {
+		defer func() {
+			if err := recover(); err != nil {
+				log.Printf("{{.RPCHandlerPrivateName}}: oneway %s panicked: %v", method, err)
+			}
+		}()
+		fn(callCtx)
+	}
+	select {
+	case _handler.onewayCalls <- call:
+		return nil
+	default:
+		return {{.RPCHandlerPrivateName}}ErrOnewayQueueFull
+	}
+}
+
+func (_handler *{{.RPCHandlerPrivateName}}) startOneway() {
+	workers, queue := _handler.opt.OnewayWorkers, _handler.opt.OnewayQueue
+	if workers <= 0 {
+		workers = {{.RPCHandlerPrivateName}}OnewayWorkers
+	}
+	if queue <= 0 {
+		queue = {{.RPCHandlerPrivateName}}OnewayQueue
+	}
+	_handler.onewayCalls = make(chan func(), queue)
+	for i := 0; i < workers; i++ {
+		go func() {
+			for call := range _handler.onewayCalls {
+				call()
+			}
+		}()
+	}
+}
+{{- end}}
@@ -0,0 +1,38 @@
+package docsvc
+
+import "context"
+
+type Service interface {
+	// Put stores the value.
+	//
+	// It replaces the previous one.
+	//meshrpc:idempotent
+	Put(
+		ctx context.Context,
+		// key of the value
+		// validate:"required,max=64"
+		key string,
+		value []byte, // raw value
+	) (
+		// version of the stored value
+		version int,
+		err error,
+	)
+	Ping() error // Ping checks the store.
+	//meshrpc:oneway
+	Drop(key string)
+}
+
+// OnewayService has a oneway method with results, it can't be exposed.
+type OnewayService interface {
+	//meshrpc:oneway
+	Notify(msg string) error
+}
+
+// RulesService has a param with an unknown validate rule, it can't be exposed.
+type RulesService interface {
+	Rename(
+		// validate:"required,maxlen=64"
+		name string,
+	) error
+}
@@ -0,0 +1,46 @@
+package lintsvc
+
+import "time"
+
+// Service has a problem of each kind the linter checks.
+type Service interface {
+	Clean(item *Item, tags []string, counts map[string]int) (total int, err error)
+	Stream(ch chan int, fn func()) error
+	Opaque(v interface{}, r Reader) (any interface{}, err error)
+	Keys(m map[Point]int, ok map[ID]int, text map[Name]int) error
+	Times(at time.Time) error
+	Unnamed(int, string) error
+	Fail() (error, error)
+}
+
+// Item is carried over the wire faithfully.
+type Item struct {
+	Name    string   `json:"name"`
+	Skipped chan int `json:"-"`
+	Meta
+}
+
+// Meta is embedded into Item.
+type Meta struct {
+	Created time.Time
+	secret  string
+}
+
+type Reader interface {
+	Read(p []byte) (int, error)
+}
+
+type Point struct {
+	X, Y int
+}
+
+type ID int64
+
+// Name is encoded as text, so it's a valid map key.
+type Name struct {
+	First, Last string
+}
+
+func (n Name) MarshalText() ([]byte, error) {
+	return []byte(n.First + " " + n.Last), nil
+}
@@ -0,0 +1,37 @@
+package schemasvc
+
+import (
+	"context"
+	"time"
+)
+
+type Service interface {
+	Put(ctx context.Context, item *Item, tags Tags) (id ID, err error)
+	Get(id ID) (*Item, bool, error)
+}
+
+type Item struct {
+	Name    string `json:"name"`
+	Price   Price  `json:"price,omitempty"`
+	Skipped int    `json:"-"`
+	Created time.Time
+	Meta
+	private int
+}
+
+type Meta struct {
+	Labels map[string]string
+}
+
+type Tags []string
+
+type ID int64
+
+// Price has a custom codec.
+type Price struct {
+	Cents int
+}
+
+func (p Price) MarshalJSON() ([]byte, error) {
+	return nil, nil
+}
@@ -0,0 +1,241 @@
+package generator
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path"
+	"path/filepath"
+	"strconv"
+)
+
+// typeResolver locates declarations of named types that are reachable
+// from the service interface methods, loading packages lazily.
+type typeResolver struct {
+	srcDir string
+	pkgs   map[string]*pkgIndex
+}
+
+func newTypeResolver(srcDir string) *typeResolver {
+	return &typeResolver{
+		srcDir: srcDir,
+		pkgs:   make(map[string]*pkgIndex),
+	}
+}
+
+// pkgIndex is a parsed package with all its type declarations and method sets indexed.
+type pkgIndex struct {
+	Pkg
+	Types   map[string]*typeDecl
+	Methods map[string]map[string]bool
+}
+
+// typeDecl is a single named type declaration.
+type typeDecl struct {
+	Pkg  *pkgIndex
+	File *ast.File
+	Spec *ast.TypeSpec
+}
+
+// typeScope is a file of the package where type expressions have been met,
+// it is required to resolve package selectors via the file imports.
+type typeScope struct {
+	Pkg  *pkgIndex
+	File *ast.File
+}
+
+// FullName returns the import path qualified name of the type, e.g. "net/http.Request".
+func (t *typeDecl) FullName() string {
+	return t.Pkg.ImportPath + "." + t.Spec.Name.Name
+}
+
+// HasMethod reports whether the type or a pointer to it has the named method declared.
+func (t *typeDecl) HasMethod(name string) bool {
+	return t.Pkg.Methods[t.Spec.Name.Name][name]
+}
+
+// Scope returns the file scope the type has been declared in.
+func (t *typeDecl) Scope() *typeScope {
+	return &typeScope{
+		Pkg:  t.Pkg,
+		File: t.File,
+	}
+}
+
+func (r *typeResolver) loadPkg(importPath string) (*pkgIndex, error) {
+	if idx, ok := r.pkgs[importPath]; ok {
+		if idx == nil {
+			return nil, fmt.Errorf("couldn't load package %s", importPath)
+		}
+		return idx, nil
+	}
+	r.pkgs[importPath] = nil
+	pkg, err := buildImport(importPath, r.srcDir)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't find package %s: %v", importPath, err)
+	}
+	idx := &pkgIndex{
+		Pkg: Pkg{
+			Package: pkg,
+			FileSet: token.NewFileSet(),
+		},
+		Types:   make(map[string]*typeDecl),
+		Methods: make(map[string]map[string]bool),
+	}
+	for _, file := range pkg.GoFiles {
+		f, err := parser.ParseFile(idx.FileSet, filepath.Join(pkg.Dir, file), nil, parser.ParseComments)
+		if err != nil {
+			continue
+		}
+		for _, decl := range f.Decls {
+			switch decl := decl.(type) {
+			case *ast.GenDecl:
+				if decl.Tok != token.TYPE {
+					continue
+				}
+				for _, spec := range decl.Specs {
+					spec := spec.(*ast.TypeSpec)
+					idx.Types[spec.Name.Name] = &typeDecl{
+						Pkg:  idx,
+						File: f,
+						Spec: spec,
+					}
+				}
+			case *ast.FuncDecl:
+				if decl.Recv == nil || len(decl.Recv.List) == 0 {
+					continue
+				}
+				recvName := receiverName(decl.Recv.List[0].Type)
+				if len(recvName) == 0 {
+					continue
+				}
+				if idx.Methods[recvName] == nil {
+					idx.Methods[recvName] = make(map[string]bool)
+				}
+				idx.Methods[recvName][decl.Name.Name] = true
+			}
+		}
+	}
+	r.pkgs[importPath] = idx
+	return idx, nil
+}
+
+// methodScope returns the file scope of the interface method declaration.
+func (r *typeResolver) methodScope(m *Method) (*typeScope, error) {
+	if m.src == nil {
+		return nil, fmt.Errorf("method %s has no source info", m.Name)
+	}
+	idx, err := r.loadPkg(m.src.Pkg.ImportPath)
+	if err != nil {
+		return nil, err
+	}
+	// the file has been parsed separately, using own fileset
+	return &typeScope{
+		Pkg:  idx,
+		File: m.src.File,
+	}, nil
+}
+
+// lookup resolves a named type referenced in the scope, either by an identifier
+// or by a package selector. Returns nil if the expression is not a named type or
+// it's a predeclared type.
+func (r *typeResolver) lookup(scope *typeScope, expr ast.Expr) (*typeDecl, error) {
+	switch expr := expr.(type) {
+	case *ast.Ident:
+		if isPredeclared(expr.Name) {
+			return nil, nil
+		}
+		if decl, ok := scope.Pkg.Types[expr.Name]; ok {
+			return decl, nil
+		}
+		return nil, fmt.Errorf("type %s not found in %s", expr.Name, scope.Pkg.ImportPath)
+	case *ast.SelectorExpr:
+		pkgIdent, ok := expr.X.(*ast.Ident)
+		if !ok {
+			return nil, nil
+		}
+		importPath, err := r.importPathOf(scope, pkgIdent.Name)
+		if err != nil {
+			return nil, err
+		}
+		idx, err := r.loadPkg(importPath)
+		if err != nil {
+			return nil, err
+		}
+		if decl, ok := idx.Types[expr.Sel.Name]; ok {
+			return decl, nil
+		}
+		return nil, fmt.Errorf("type %s not found in %s", expr.Sel.Name, importPath)
+	}
+	return nil, nil
+}
+
+// importPathOf resolves the package name used in file into its import path.
+func (r *typeResolver) importPathOf(scope *typeScope, name string) (string, error) {
+	var unnamed []string
+	for _, imp := range scope.File.Imports {
+		importPath, err := strconv.Unquote(imp.Path.Value)
+		if err != nil {
+			continue
+		}
+		if imp.Name != nil {
+			if imp.Name.Name == name {
+				return importPath, nil
+			}
+			continue
+		}
+		if path.Base(importPath) == name {
+			return importPath, nil
+		}
+		unnamed = append(unnamed, importPath)
+	}
+	// package name may differ from the last element of its import path
+	for _, importPath := range unnamed {
+		if pkg, err := buildImport(importPath, r.srcDir); err == nil && pkg.Name == name {
+			return importPath, nil
+		}
+	}
+	return "", fmt.Errorf("package %s is not imported in %s", name, scope.Pkg.ImportPath)
+}
+
+// receiverName returns the base type name of a method receiver, e.g. "T" for "*T".
+func receiverName(expr ast.Expr) string {
+	switch expr := expr.(type) {
+	case *ast.Ident:
+		return expr.Name
+	case *ast.StarExpr:
+		return receiverName(expr.X)
+	case *ast.ParenExpr:
+		return receiverName(expr.X)
+	}
+	return ""
+}
+
+var predeclaredTypes = map[string]bool{
+	"bool":       true,
+	"byte":       true,
+	"complex64":  true,
+	"complex128": true,
+	"error":      true,
+	"float32":    true,
+	"float64":    true,
+	"int":        true,
+	"int8":       true,
+	"int16":      true,
+	"int32":      true,
+	"int64":      true,
+	"rune":       true,
+	"string":     true,
+	"uint":       true,
+	"uint8":      true,
+	"uint16":     true,
+	"uint32":     true,
+	"uint64":     true,
+	"uintptr":    true,
+	"any":        true,
+}
+
+func isPredeclared(name string) bool {
+	return predeclaredTypes[name]
+}
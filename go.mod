@@ -1,8 +1,9 @@
 module github.com/astranet/meshRPC
 
-go 1.12
+go 1.13
 
 require (
+	github.com/BurntSushi/toml v0.3.1
 	github.com/Hatch1fy/errors v0.0.0-20190124213112-81fd84668c75 // indirect
 	github.com/Hatch1fy/httpserve v0.0.0-20190613164313-099d942969a2
 	github.com/astranet/astranet v1.2.0-rc3
@@ -21,4 +22,5 @@ require (
 	github.com/xlab/closer v0.0.0-20190328110542-03326addb7c2
 	github.com/xlab/treeprint v0.0.0-20181112141820-a009c3971eca
 	golang.org/x/tools v0.0.0-20190903025054-afe7f8212f0d
+	gopkg.in/yaml.v2 v2.2.2
 )
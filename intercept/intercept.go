@@ -0,0 +1,106 @@
+// Package intercept defines interceptors of RPC calls, that are shared by generated
+// handlers and clients of all services. Interceptors are the place for logging,
+// metrics, auth and tracing: they see each call before and after it's made.
+//
+//	logCalls := func(ctx context.Context, call *intercept.Call, next intercept.Invoker) error {
+//		started := time.Now()
+//		err := next(ctx, call)
+//		log.Printf("%s.%s took %v: %v", call.Service, call.Method, time.Since(started), err)
+//		return err
+//	}
+//	handler := greeter.NewRPCHandler(svc, &greeter.RPCHandlerOptions{
+//		Interceptors: []intercept.Interceptor{logCalls},
+//	})
+package intercept
+
+import (
+	"context"
+	"errors"
+	"net/http"
+)
+
+// Call describes a call of a service method.
+type Call struct {
+	// Service is the name of the service interface, e.g. "greeter.Service".
+	Service string
+	// Method is the name of the called method, e.g. "Greet".
+	Method string
+	// Request is the request model of the method, e.g. *GreetRequest. In handlers
+	// it's decoded already.
+	Request interface{}
+	// Response is the response model of the method, e.g. *GreetResponse,
+	// it's filled in once the call completes without an error.
+	Response interface{}
+	// Header is the header of the HTTP request. Interceptors of clients may add
+	// headers to send, interceptors of handlers may read the received ones.
+	Header http.Header
+	// Server is set for calls intercepted by handlers, as opposed to clients.
+	Server bool
+}
+
+// Invoker makes the call, or passes it to the next interceptor of the chain.
+type Invoker func(ctx context.Context, call *Call) error
+
+// Interceptor wraps calls, it must call next to proceed with the call, unless it
+// rejects one. The returned error becomes the error of the call.
+type Interceptor func(ctx context.Context, call *Call, next Invoker) error
+
+// Invoke passes the call through the interceptors in order, the first one is the
+// outermost, then makes it with fn.
+func Invoke(ctx context.Context, interceptors []Interceptor, call *Call, fn Invoker) error {
+	if len(interceptors) == 0 {
+		return fn(ctx, call)
+	}
+	return interceptors[0](ctx, call, func(ctx context.Context, call *Call) error {
+		return Invoke(ctx, interceptors[1:], call, fn)
+	})
+}
+
+// Chain combines the interceptors into one, the first one is the outermost.
+func Chain(interceptors ...Interceptor) Interceptor {
+	return func(ctx context.Context, call *Call, next Invoker) error {
+		return Invoke(ctx, interceptors, call, next)
+	}
+}
+
+// StatusError is an error that handlers respond with the specific HTTP status,
+// e.g. interceptors may reject calls with 401 Unauthorized or 403 Forbidden.
+type StatusError struct {
+	Status int
+	Err    error
+}
+
+func (e *StatusError) Error() string {
+	return e.Err.Error()
+}
+
+// Cause returns the underlying error, compatible with github.com/pkg/errors.
+func (e *StatusError) Cause() error {
+	return e.Err
+}
+
+// Unwrap returns the underlying error, compatible with errors.Is.
+func (e *StatusError) Unwrap() error {
+	return e.Err
+}
+
+// StatusClientClosedRequest is the status of calls that were cancelled by the caller,
+// it's never seen by the caller but still counts in logs and metrics.
+const StatusClientClosedRequest = 499
+
+// Status returns the HTTP status handlers respond to the error with, it's
+// 400 Bad Request unless the error is a *StatusError or an error of the context
+// of the call, wrapped or not: calls that run out of time end with 504 Gateway Timeout, and
+// cancelled calls with StatusClientClosedRequest.
+func Status(err error) int {
+	var statusErr *StatusError
+	switch {
+	case errors.As(err, &statusErr):
+		return statusErr.Status
+	case errors.Is(err, context.DeadlineExceeded):
+		return http.StatusGatewayTimeout
+	case errors.Is(err, context.Canceled):
+		return StatusClientClosedRequest
+	}
+	return http.StatusBadRequest
+}
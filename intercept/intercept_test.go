@@ -0,0 +1,105 @@
+package intercept
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+// recorder returns an interceptor that appends its name to the trace before and after the call.
+func recorder(trace *[]string, name string) Interceptor {
+	return func(ctx context.Context, call *Call, next Invoker) error {
+		*trace = append(*trace, name+" before")
+		err := next(ctx, call)
+		*trace = append(*trace, name+" after")
+		return err
+	}
+}
+
+func TestInvoke(t *testing.T) {
+	var trace []string
+	call := &Call{Method: "Greet", Header: make(http.Header)}
+	addHeader := func(ctx context.Context, call *Call, next Invoker) error {
+		call.Header.Set("X-Test", "yes")
+		return next(ctx, call)
+	}
+	interceptors := []Interceptor{
+		recorder(&trace, "outer"),
+		Chain(recorder(&trace, "first"), addHeader, recorder(&trace, "second")),
+		recorder(&trace, "inner"),
+	}
+	err := Invoke(context.Background(), interceptors, call, func(ctx context.Context, call *Call) error {
+		trace = append(trace, "call with "+call.Header.Get("X-Test"))
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"outer before",
+		"first before",
+		"second before",
+		"inner before",
+		"call with yes",
+		"inner after",
+		"second after",
+		"first after",
+		"outer after",
+	}
+	if !reflect.DeepEqual(trace, want) {
+		t.Errorf("trace = %q, want %q", trace, want)
+	}
+}
+
+func TestInvokeReject(t *testing.T) {
+	var trace []string
+	errDenied := &StatusError{Status: http.StatusForbidden, Err: errors.New("denied")}
+	deny := func(ctx context.Context, call *Call, next Invoker) error {
+		return errDenied
+	}
+	err := Invoke(context.Background(), []Interceptor{recorder(&trace, "outer"), deny}, &Call{},
+		func(ctx context.Context, call *Call) error {
+			t.Error("rejected call has been made")
+			return nil
+		})
+	if err != errDenied {
+		t.Errorf("Invoke() = %v, want %v", err, errDenied)
+	}
+	if want := []string{"outer before", "outer after"}; !reflect.DeepEqual(trace, want) {
+		t.Errorf("trace = %q, want %q", trace, want)
+	}
+
+	called := false
+	err = Invoke(context.Background(), nil, &Call{}, func(ctx context.Context, call *Call) error {
+		called = true
+		return nil
+	})
+	if err != nil || !called {
+		t.Errorf("Invoke() without interceptors = %v, called %v", err, called)
+	}
+}
+
+func TestStatus(t *testing.T) {
+	forbidden := &StatusError{Status: http.StatusForbidden, Err: errors.New("admins only")}
+	tests := []struct {
+		err  error
+		want int
+	}{
+		{errors.New("bad name"), http.StatusBadRequest},
+		{context.DeadlineExceeded, http.StatusGatewayTimeout},
+		{fmt.Errorf("greet: %w", context.DeadlineExceeded), http.StatusGatewayTimeout},
+		{context.Canceled, StatusClientClosedRequest},
+		{fmt.Errorf("greet: %w", context.Canceled), StatusClientClosedRequest},
+		{forbidden, http.StatusForbidden},
+		{fmt.Errorf("greet: %w", forbidden), http.StatusForbidden},
+		{&StatusError{Status: http.StatusServiceUnavailable, Err: context.Canceled}, http.StatusServiceUnavailable},
+	}
+	for _, tt := range tests {
+		if got := Status(tt.err); got != tt.want {
+			t.Errorf("Status(%v) = %d, want %d", tt.err, got, tt.want)
+		}
+	}
+}
@@ -1,7 +1,6 @@
 package main
 
 import (
-	"bytes"
 	"fmt"
 	"log"
 	"os"
@@ -10,6 +9,8 @@ import (
 	"text/template"
 
 	"github.com/jawher/mow.cli"
+
+	"github.com/astranet/meshRPC/generator"
 )
 
 var app = cli.App("meshRPC", "Tool for generating an RPC handler and cluster client for any service interface.")
@@ -21,6 +22,9 @@ func init() {
 
 func main() {
 	app.Command("expose", "Creates RPC handler/client that exposes provided service into a mesh cluster.", exposeCmd)
+	app.Command("lint", "Reports service interface types that can't be carried over the wire faithfully.", lintCmd)
+	app.Command("generate", "Regenerates all service interfaces declared in the project config (meshrpc.toml or meshrpc.yaml).", generateCmd)
+	app.Command("diff", "Reports breaking changes of the service interface since the base git revision.", diffCmd)
 	if err := app.Run(os.Args); err != nil {
 		log.Fatalln(err)
 	}
@@ -31,118 +35,298 @@ func exposeCmd(c *cli.Cmd) {
 	packageName := c.StringOpt("P pkg-name", "foo", "Must specify the package name.")
 	featurePrefix := c.StringOpt("M module-prefix", "", "Optional feature prefix to distinguish multiple service interfaces in the same package.")
 	agreeAll := c.BoolOpt("y yes", false, "Agree to all prompts automatically.")
-	c.Spec = "-P [-M] [-y] [SRC]"
+	dryRun := c.BoolOpt("dry-run", false, "Only show actions to be committed, without applying them.")
+	showDiff := c.BoolOpt("diff", false, "Show a unified diff between existing files and the generated ones, implies --dry-run.")
+	checkOnly := c.BoolOpt("check", false, "Exit with non-zero code if generated files are not up to date, implies --dry-run.")
+	clientOut := c.StringOpt("o client-out", "", "Optional dir of a standalone client package that doesn't depend on the service package.")
+	serverKind := c.StringOpt("server", generator.ServerHTTPServe, "Handler flavour: httpserve or nethttp.")
+	templatesDir := c.StringOpt("templates", "", "Optional dir with templates that override the embedded ones by file name.")
+	watch := c.BoolOpt("watch", false, "Keep watching the service interface and reachable types, regenerate files on change.")
+	c.Spec = "-P [-M] [-y] [--dry-run] [--diff] [--check] [-o] [--server] [--templates] [--watch] [SRC]"
 
 	c.Action = func() {
-		ctx := &TemplateContext{
-			PackageName:   strings.ToLower(*packageName),
-			FeaturePrefix: strings.Title(*featurePrefix),
-
-			RPCHandlerPrivateName: rpcHandlerPrivateName(*featurePrefix),
-			RPCClientPrivateName:  rpcClientPrivateName(*featurePrefix),
+		tpls, err := generator.LoadTemplates(*templatesDir)
+		if err != nil {
+			log.Fatalf("Failed to load templates: %v", err)
 		}
-		var basePath string
-		if info, err := os.Stat(*targetPath); err != nil {
-			log.Fatalln("Failed to read SRC dir:", *targetPath)
-		} else if !info.IsDir() {
-			basePath = filepath.Dir(*targetPath)
-		} else {
-			basePath = *targetPath
+		basePath := srcBasePath(*targetPath)
+		opt := &generator.Options{
+			PackageName:   *packageName,
+			FeaturePrefix: *featurePrefix,
+			Server:        *serverKind,
+			ClientOut:     *clientOut,
+			Templates:     tpls,
+		}
+		if *watch {
+			watchExpose(basePath, opt)
+			return
 		}
-		basePath, _ = filepath.Abs(basePath)
-		if len(*projectDir) == 0 {
-			*projectDir = "."
+		actionQueue, _, err := exposeActions(basePath, opt)
+		if err != nil {
+			log.Fatalln(err)
 		}
-		*projectDir, _ = filepath.Abs(*projectDir)
+		applyQueue(actionQueue, &applyOptions{
+			AgreeAll:  *agreeAll,
+			DryRun:    *dryRun,
+			ShowDiff:  *showDiff,
+			CheckOnly: *checkOnly,
+		})
+	}
+}
+
+func generateCmd(c *cli.Cmd) {
+	configPath := c.StringOpt("c config", "", "Path to the project config, by default it's looked up in the working dir and its parents.")
+	agreeAll := c.BoolOpt("y yes", false, "Agree to all prompts automatically.")
+	dryRun := c.BoolOpt("dry-run", false, "Only show actions to be committed, without applying them.")
+	showDiff := c.BoolOpt("diff", false, "Show a unified diff between existing files and the generated ones, implies --dry-run.")
+	checkOnly := c.BoolOpt("check", false, "Exit with non-zero code if generated files are not up to date, implies --dry-run.")
+	c.Spec = "[-c] [-y] [--dry-run] [--diff] [--check]"
 
-		filePrefix := strings.ToLower(*featurePrefix) + "_"
-		if len(*featurePrefix) == 0 {
-			filePrefix = ""
+	c.Action = func() {
+		path := *configPath
+		if len(path) == 0 {
+			var err error
+			if path, err = findConfig("."); err != nil {
+				log.Fatalf("Failed to find the project config: %v", err)
+			}
 		}
-		ifaceName := fmt.Sprintf("%s.%sService", ctx.PackageName, ctx.FeaturePrefix)
-		iface, err := NewMethodsCollection(ifaceName, basePath)
+		cfg, err := loadConfig(path)
 		if err != nil {
-			log.Fatalf("Failed to locate %s interface: %v", ifaceName, err)
-			return
+			log.Fatalf("Failed to load %s: %v", path, err)
 		}
-		actionQueue := NewQueue(
-			CheckDirAction(basePath),
-		)
-		ctx.JsonHandlerInterfaceBody = genRPCHandlerInterface(iface)
-		ctx.JsonHandlerImplementationBody = genRPCHandlerImplementation(ctx.RPCHandlerPrivateName, ctx.FeaturePrefix, iface)
-		actionQueue = append(actionQueue,
-			OverwriteFileAction(filepath.Join(basePath, filePrefix+"handler_gen.go"), ctx.RenderInto(rpcHandlerTemplate)),
-		)
-		ctx.JsonClientImplementationBody = genServiceClientImplementation(ctx.RPCClientPrivateName, ctx.FeaturePrefix, iface)
-		actionQueue = append(actionQueue,
-			OverwriteFileAction(filepath.Join(basePath, filePrefix+"client_gen.go"), ctx.RenderInto(rpcClientTemplate)),
-		)
-		fmt.Println(actionQueue.Description())
-		agree := *agreeAll
-		if !agree {
-			agree = cliConfirm("Are you sure to apply these changes?")
-			if !agree {
-				log.Println("Action cancelled.")
-				return
+		// paths are shown relative to the config dir
+		*projectDir = filepath.Dir(path)
+
+		tplSets := make(map[string]*template.Template)
+		var actionQueue Queue
+		for _, svc := range cfg.Services {
+			tpls, ok := tplSets[svc.Templates]
+			if !ok {
+				if tpls, err = generator.LoadTemplates(svc.Templates); err != nil {
+					log.Fatalf("Failed to load templates: %v", err)
+				}
+				tplSets[svc.Templates] = tpls
 			}
+			svcQueue, _, err := exposeActions(srcBasePath(svc.Src), &generator.Options{
+				PackageName:   svc.Package,
+				FeaturePrefix: svc.Prefix,
+				Server:        svc.Server,
+				ClientOut:     svc.ClientOut,
+				Templates:     tpls,
+			})
+			if err != nil {
+				log.Fatalln(err)
+			}
+			actionQueue = append(actionQueue, svcQueue...)
 		}
-		if !actionQueue.Exec() {
+		applyQueue(actionQueue, &applyOptions{
+			AgreeAll:  *agreeAll,
+			DryRun:    *dryRun,
+			ShowDiff:  *showDiff,
+			CheckOnly: *checkOnly,
+		})
+	}
+}
+
+// exposeActions returns actions that generate the RPC handler and the client
+// of the service interface declared in basePath, along with its wire schema.
+func exposeActions(basePath string, opt *generator.Options) (Queue, *generator.Schema, error) {
+	res, err := generator.Generate(basePath, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+	actionQueue := NewQueue(
+		CheckDirAction(basePath),
+	)
+	for _, f := range res.Files {
+		if dir := filepath.Dir(f.Path); dir != basePath {
+			actionQueue = append(actionQueue, NewDirAction(dir))
+		}
+		actionQueue = append(actionQueue, OverwriteFileAction(f.Path, f.Contents))
+	}
+	return actionQueue, res.Schema, nil
+}
+
+type applyOptions struct {
+	AgreeAll  bool
+	DryRun    bool
+	ShowDiff  bool
+	CheckOnly bool
+}
+
+// applyQueue prints the queue, then either previews it, checks it against existing files
+// or executes it. Previews with a diff don't touch the files, the same as dry runs.
+func applyQueue(actionQueue Queue, opt *applyOptions) {
+	fmt.Println(actionQueue.Description())
+	if opt.ShowDiff || opt.CheckOnly {
+		diff, changed := actionQueue.Diff()
+		if opt.ShowDiff {
+			fmt.Print(diff)
+		}
+		if opt.CheckOnly && changed {
+			log.Println("Generated files are not up to date.")
 			os.Exit(1)
+		}
+		return
+	}
+	if opt.DryRun {
+		return
+	}
+	agree := opt.AgreeAll
+	if !agree {
+		agree = cliConfirm("Are you sure to apply these changes?")
+		if !agree {
+			log.Println("Action cancelled.")
 			return
 		}
 	}
+	if !actionQueue.Exec() {
+		os.Exit(1)
+		return
+	}
 }
 
-type TemplateContext struct {
-	PackageName   string
-	FeaturePrefix string
+func lintCmd(c *cli.Cmd) {
+	targetPath := c.StringArg("SRC", ".", "Target Go source file or a package with service definitions.")
+	packageName := c.StringOpt("P pkg-name", "", "Package name of the service interface, by default services of the project config are linted.")
+	featurePrefix := c.StringOpt("M module-prefix", "", "Optional feature prefix to distinguish multiple service interfaces in the same package.")
+	configPath := c.StringOpt("c config", "", "Path to the project config, by default it's looked up in the working dir and its parents.")
+	ignoreChecks := c.StringsOpt("I ignore", nil, "Checks to skip: chan, func, iface, unexported, map-key, time, unnamed.")
+	c.Spec = "[-P] [-M] [-c] [-I...] [SRC]"
 
-	RPCHandlerPrivateName string
-	RPCClientPrivateName  string
+	c.Action = func() {
+		ignored := make(map[generator.LintCheck]bool, len(*ignoreChecks))
+		for _, check := range *ignoreChecks {
+			ignored[generator.LintCheck(check)] = true
+		}
+		if len(*packageName) > 0 {
+			if lintService(*packageName, *featurePrefix, srcBasePath(*targetPath), ignored) > 0 {
+				os.Exit(1)
+			}
+			return
+		}
+		// only the exposed interfaces, helper interfaces of the packages are skipped
+		path := *configPath
+		if len(path) == 0 {
+			var err error
+			if path, err = findConfig("."); err != nil {
+				log.Fatalf("Failed to find the project config, specify the service with -P: %v", err)
+			}
+		}
+		if lintProject(path, ignored) > 0 {
+			os.Exit(1)
+		}
+	}
+}
 
-	JsonHandlerInterfaceBody      string
-	JsonHandlerImplementationBody string
-	CapnHandlerInterfaceBody      string
-	CapnHandlerImplementationBody string
+// lintProject lints the services declared in the project config, and returns
+// the number of issues found.
+func lintProject(configPath string, ignored map[generator.LintCheck]bool) int {
+	cfg, err := loadConfig(configPath)
+	if err != nil {
+		log.Fatalf("Failed to load %s: %v", configPath, err)
+	}
+	// paths are shown relative to the config dir
+	*projectDir = filepath.Dir(configPath)
+	var found int
+	for _, svc := range cfg.Services {
+		found += lintService(svc.Package, svc.Prefix, srcBasePath(svc.Src), ignored)
+	}
+	return found
+}
 
-	JsonClientInterfaceBody      string
-	JsonClientImplementationBody string
-	CapnClientInterfaceBody      string
-	CapnClientImplementationBody string
+// lintService prints wire-compatibility issues of the service interface, apart from
+// the ignored checks, and returns their number.
+func lintService(packageName, featurePrefix, basePath string, ignored map[generator.LintCheck]bool) int {
+	iface := loadServiceInterface(strings.ToLower(packageName), strings.Title(featurePrefix), basePath)
+	issues, err := generator.LintMethods(iface, basePath)
+	if err != nil {
+		log.Fatalf("Failed to lint %s interface: %v", iface.ID, err)
+	}
+	var found int
+	for _, issue := range issues {
+		if ignored[issue.Check] {
+			continue
+		}
+		found++
+		fmt.Println(issue)
+	}
+	if found > 0 {
+		log.Printf("Found %d wire-compatibility issues in %s", found, iface)
+	}
+	return found
 }
 
-//go:generate go-bindata -o bindata.go -pkg main templates/
-var (
-	rpcHandlerTemplate = template.Must(
-		template.New("handler_rpc.go").Parse(
-			string(MustAsset("templates/handler_rpc_go.tpl")),
-		),
-	)
-	rpcClientTemplate = template.Must(
-		template.New("client_rpc.go").Parse(
-			string(MustAsset("templates/client_rpc_go.tpl")),
-		),
-	)
-)
+func diffCmd(c *cli.Cmd) {
+	targetPath := c.StringArg("SRC", ".", "Target Go source file or a package with service definitions.")
+	packageName := c.StringOpt("P pkg-name", "foo", "Must specify the package name.")
+	featurePrefix := c.StringOpt("M module-prefix", "", "Optional feature prefix to distinguish multiple service interfaces in the same package.")
+	baseRef := c.StringOpt("base", "HEAD", "Git revision to compare the service interface against.")
+	c.Spec = "-P [-M] [--base] [SRC]"
 
-func (t *TemplateContext) RenderInto(tpl *template.Template) []byte {
-	buf := new(bytes.Buffer)
-	if err := tpl.ExecuteTemplate(buf, tpl.Name(), t); err != nil {
-		panic(err)
+	c.Action = func() {
+		basePath := srcBasePath(*targetPath)
+		pkgName := strings.ToLower(*packageName)
+		prefix := strings.Title(*featurePrefix)
+		head := loadServiceInterface(pkgName, prefix, basePath)
+		headSchema, err := generator.NewSchema(head, basePath)
+		if err != nil {
+			log.Fatalf("Failed to inspect %s interface: %v", head.ID, err)
+		}
+		baseRevPath, cleanup, err := checkoutRevision(*baseRef, basePath)
+		if err != nil {
+			log.Fatalf("Failed to checkout %s: %v", *baseRef, err)
+		}
+		defer cleanup()
+		base, err := generator.NewMethodsCollection(fmt.Sprintf("%s.%sService", pkgName, prefix), baseRevPath)
+		if err != nil {
+			cleanup()
+			log.Fatalf("Failed to locate interface at %s: %v", *baseRef, err)
+		}
+		baseSchema, err := generator.NewSchema(base, baseRevPath)
+		if err != nil {
+			cleanup()
+			log.Fatalf("Failed to inspect interface at %s: %v", *baseRef, err)
+		}
+		var breaking int
+		for _, change := range generator.DiffSchemas(baseSchema, headSchema) {
+			if change.Breaking {
+				breaking++
+			}
+			fmt.Println(change)
+		}
+		if breaking > 0 {
+			cleanup()
+			log.Printf("Found %d breaking changes in %s since %s", breaking, head, *baseRef)
+			os.Exit(1)
+		}
 	}
-	return buf.Bytes()
 }
 
-func rpcHandlerPrivateName(featurePrefix string) string {
-	if len(featurePrefix) == 0 {
-		return "rpcHandler"
+// srcBasePath returns the absolute dir of the service sources,
+// it also resolves the project root dir.
+func srcBasePath(targetPath string) string {
+	var basePath string
+	if info, err := os.Stat(targetPath); err != nil {
+		log.Fatalln("Failed to read SRC dir:", targetPath)
+	} else if !info.IsDir() {
+		basePath = filepath.Dir(targetPath)
+	} else {
+		basePath = targetPath
+	}
+	basePath, _ = filepath.Abs(basePath)
+	if len(*projectDir) == 0 {
+		*projectDir = "."
 	}
-	return strings.ToLower(string(featurePrefix[0])) + featurePrefix[1:] + "RPCHandler"
+	*projectDir, _ = filepath.Abs(*projectDir)
+	return basePath
 }
 
-func rpcClientPrivateName(featurePrefix string) string {
-	if len(featurePrefix) == 0 {
-		return "rpcClient"
+func loadServiceInterface(packageName, featurePrefix, basePath string) *generator.MethodsCollection {
+	ifaceName := fmt.Sprintf("%s.%sService", packageName, featurePrefix)
+	iface, err := generator.NewMethodsCollection(ifaceName, basePath)
+	if err != nil {
+		log.Fatalf("Failed to locate %s interface: %v", ifaceName, err)
 	}
-	return strings.ToLower(string(featurePrefix[0])) + featurePrefix[1:] + "RPCClient"
+	return iface
 }
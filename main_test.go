@@ -0,0 +1,53 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestApplyQueuePreview(t *testing.T) {
+	dir, err := ioutil.TempDir("", "meshrpc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "handler_gen.txt")
+	if err := ioutil.WriteFile(path, []byte("old\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	// previews don't touch the files
+	for _, opt := range []*applyOptions{
+		{AgreeAll: true, DryRun: true},
+		{AgreeAll: true, ShowDiff: true},
+	} {
+		applyQueue(NewQueue(OverwriteFileAction(path, []byte("new\n"))), opt)
+		if data, _ := ioutil.ReadFile(path); string(data) != "old\n" {
+			t.Errorf("applyQueue(%+v) has written the file: %q", *opt, data)
+		}
+	}
+	applyQueue(NewQueue(OverwriteFileAction(path, []byte("new\n"))), &applyOptions{AgreeAll: true})
+	if data, _ := ioutil.ReadFile(path); string(data) != "new\n" {
+		t.Errorf("applyQueue() has not written the file: %q", data)
+	}
+}
+
+func TestLintService(t *testing.T) {
+	if n := lintService("greeter", "", srcBasePath("example/greeter/service"), nil); n != 0 {
+		t.Errorf("lintService() found %d issues of greeter.Service, want none", n)
+	}
+
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+	defer func(prev string) { *projectDir = prev }(*projectDir)
+	src, err := filepath.Abs("example/greeter/service")
+	if err != nil {
+		t.Fatal(err)
+	}
+	configPath := filepath.Join(dir, "meshrpc.toml")
+	writeFile(t, configPath, "[[services]]\nsrc = \""+src+"\"\npackage = \"greeter\"\n", 0644)
+	if n := lintProject(configPath, nil); n != 0 {
+		t.Errorf("lintProject() found %d issues, want none", n)
+	}
+}
@@ -0,0 +1,237 @@
+// Package metrics collects metrics of RPC calls with an interceptor and writes them
+// in the Prometheus text format, so no Prometheus client library is needed:
+//
+//	handler := greeter.NewRPCHandler(svc, &greeter.RPCHandlerOptions{
+//		Interceptors: []intercept.Interceptor{metrics.Interceptor()},
+//	})
+//	http.Handle("/metrics", metrics.DefaultRegistry)
+//
+// Calls are counted per side (server, client or proxy), service and method, along with
+// errors by status and a latency histogram. Calls proxied by the cluster to public routes
+// are recorded into DefaultRegistry by the cluster itself.
+package metrics
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/astranet/meshRPC/intercept"
+)
+
+// DefaultBuckets are upper bounds of latency histogram buckets, in seconds.
+var DefaultBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+// DefaultRegistry is used by Interceptor and served by the cluster at /metrics.
+var DefaultRegistry = NewRegistry(nil)
+
+// Interceptor returns an interceptor that collects metrics into DefaultRegistry.
+func Interceptor() intercept.Interceptor {
+	return DefaultRegistry.Interceptor()
+}
+
+type Options struct {
+	// Buckets are upper bounds of latency histogram buckets in seconds,
+	// DefaultBuckets are used if not set.
+	Buckets []float64
+}
+
+func checkOptions(opt *Options) *Options {
+	if opt == nil {
+		opt = &Options{}
+	}
+	if len(opt.Buckets) == 0 {
+		opt.Buckets = DefaultBuckets
+	}
+	return opt
+}
+
+// Registry holds metrics of calls.
+type Registry struct {
+	opt *Options
+
+	mux    sync.Mutex
+	series map[seriesKey]*series
+}
+
+func NewRegistry(opt *Options) *Registry {
+	return &Registry{
+		opt:    checkOptions(opt),
+		series: make(map[seriesKey]*series),
+	}
+}
+
+type seriesKey struct {
+	side    string
+	service string
+	method  string
+}
+
+type series struct {
+	requests uint64
+	errors   map[string]uint64
+	buckets  []uint64
+	sum      float64
+}
+
+// Interceptor returns an interceptor that collects metrics into the registry,
+// it may be used by handlers and clients at once.
+func (r *Registry) Interceptor() intercept.Interceptor {
+	return func(ctx context.Context, call *intercept.Call, next intercept.Invoker) error {
+		started := time.Now()
+		err := next(ctx, call)
+		r.observe(call, time.Since(started), err)
+		return err
+	}
+}
+
+func (r *Registry) observe(call *intercept.Call, took time.Duration, err error) {
+	side := "client"
+	if call.Server {
+		side = "server"
+	}
+	var status string
+	if err != nil {
+		status = errorStatus(call, err)
+	}
+	r.record(seriesKey{
+		side:    side,
+		service: call.Service,
+		method:  call.Method,
+	}, took, status)
+}
+
+// Observe records a call that is not intercepted, e.g. one proxied by the cluster,
+// by the status of its response. Statuses from 400 on count as errors.
+func (r *Registry) Observe(side, service, method string, took time.Duration, status int) {
+	var errStatus string
+	if status >= 400 {
+		errStatus = strconv.Itoa(status)
+	}
+	r.record(seriesKey{
+		side:    side,
+		service: service,
+		method:  method,
+	}, took, errStatus)
+}
+
+// record adds the call to the series, errStatus is empty for calls that succeeded.
+func (r *Registry) record(key seriesKey, took time.Duration, errStatus string) {
+	seconds := took.Seconds()
+
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	s, ok := r.series[key]
+	if !ok {
+		s = &series{
+			errors:  make(map[string]uint64),
+			buckets: make([]uint64, len(r.opt.Buckets)),
+		}
+		r.series[key] = s
+	}
+	s.requests++
+	s.sum += seconds
+	for i, bound := range r.opt.Buckets {
+		if seconds <= bound {
+			s.buckets[i]++
+		}
+	}
+	if len(errStatus) > 0 {
+		s.errors[errStatus]++
+	}
+}
+
+// errorStatus returns the status label of the error. Handlers respond to errors
+// with intercept.Status, errors of clients have no status unless the service
+// has responded with one.
+func errorStatus(call *intercept.Call, err error) string {
+	var statusErr *intercept.StatusError
+	if errors.As(err, &statusErr) {
+		return strconv.Itoa(statusErr.Status)
+	} else if call.Server {
+		return strconv.Itoa(intercept.Status(err))
+	}
+	return "error"
+}
+
+// ServeHTTP writes the metrics in the Prometheus text format.
+func (r *Registry) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	r.WriteTo(w)
+}
+
+// WriteTo writes the metrics in the Prometheus text format.
+func (r *Registry) WriteTo(w io.Writer) (int64, error) {
+	r.mux.Lock()
+	keys := make([]seriesKey, 0, len(r.series))
+	for key := range r.series {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		a, b := keys[i], keys[j]
+		if a.side != b.side {
+			return a.side < b.side
+		} else if a.service != b.service {
+			return a.service < b.service
+		}
+		return a.method < b.method
+	})
+	requests := new(bytes.Buffer)
+	errorLines := new(bytes.Buffer)
+	durations := new(bytes.Buffer)
+	for _, key := range keys {
+		s := r.series[key]
+		labels := key.labels()
+		fmt.Fprintf(requests, "meshrpc_requests_total{%s} %d\n", labels, s.requests)
+
+		statuses := make([]string, 0, len(s.errors))
+		for status := range s.errors {
+			statuses = append(statuses, status)
+		}
+		sort.Strings(statuses)
+		for _, status := range statuses {
+			fmt.Fprintf(errorLines, "meshrpc_errors_total{%s,status=%q} %d\n", labels, status, s.errors[status])
+		}
+
+		for i, bound := range r.opt.Buckets {
+			le := strconv.FormatFloat(bound, 'g', -1, 64)
+			fmt.Fprintf(durations, "meshrpc_request_duration_seconds_bucket{%s,le=%q} %d\n", labels, le, s.buckets[i])
+		}
+		fmt.Fprintf(durations, "meshrpc_request_duration_seconds_bucket{%s,le=\"+Inf\"} %d\n", labels, s.requests)
+		fmt.Fprintf(durations, "meshrpc_request_duration_seconds_sum{%s} %g\n", labels, s.sum)
+		fmt.Fprintf(durations, "meshrpc_request_duration_seconds_count{%s} %d\n", labels, s.requests)
+	}
+	r.mux.Unlock()
+
+	buf := new(bytes.Buffer)
+	buf.WriteString("# HELP meshrpc_requests_total Number of RPC calls.\n")
+	buf.WriteString("# TYPE meshrpc_requests_total counter\n")
+	requests.WriteTo(buf)
+	buf.WriteString("# HELP meshrpc_errors_total Number of failed RPC calls by status.\n")
+	buf.WriteString("# TYPE meshrpc_errors_total counter\n")
+	errorLines.WriteTo(buf)
+	buf.WriteString("# HELP meshrpc_request_duration_seconds Latency of RPC calls.\n")
+	buf.WriteString("# TYPE meshrpc_request_duration_seconds histogram\n")
+	durations.WriteTo(buf)
+	return buf.WriteTo(w)
+}
+
+func (k seriesKey) labels() string {
+	return fmt.Sprintf("side=%s,service=%s,method=%s",
+		quoteLabel(k.side), quoteLabel(k.service), quoteLabel(k.method))
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// quoteLabel quotes the label value as the text format requires.
+func quoteLabel(v string) string {
+	return `"` + labelEscaper.Replace(v) + `"`
+}
@@ -0,0 +1,86 @@
+package metrics
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/astranet/meshRPC/intercept"
+)
+
+func TestRegistry(t *testing.T) {
+	r := NewRegistry(&Options{
+		Buckets: []float64{0.5, 1},
+	})
+	interceptor := r.Interceptor()
+	calls := []struct {
+		call *intercept.Call
+		err  error
+	}{
+		{&intercept.Call{Service: "greeter.Service", Method: "Greet", Server: true}, nil},
+		{&intercept.Call{Service: "greeter.Service", Method: "Greet", Server: true}, errors.New("invalid name")},
+		{&intercept.Call{Service: "greeter.Service", Method: "Greet", Server: true}, context.Canceled},
+		{&intercept.Call{Service: "greeter.Service", Method: "Greet"}, errors.New("connection refused")},
+		{&intercept.Call{Service: "greeter.Service", Method: "Greet"}, &intercept.StatusError{
+			Status: http.StatusServiceUnavailable,
+			Err:    errors.New("unavailable"),
+		}},
+	}
+	for _, c := range calls {
+		err := interceptor(context.Background(), c.call, func(ctx context.Context, call *intercept.Call) error {
+			return c.err
+		})
+		if err != c.err {
+			t.Errorf("interceptor returned %v, want %v", err, c.err)
+		}
+	}
+	r.Observe("proxy", "greeter", "Greet", 700*time.Millisecond, http.StatusOK)
+	r.Observe("proxy", "greeter", "Greet", 2*time.Second, http.StatusBadGateway)
+
+	buf := new(bytes.Buffer)
+	if _, err := r.WriteTo(buf); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	for _, line := range []string{
+		`meshrpc_requests_total{side="client",service="greeter.Service",method="Greet"} 2`,
+		`meshrpc_requests_total{side="proxy",service="greeter",method="Greet"} 2`,
+		`meshrpc_requests_total{side="server",service="greeter.Service",method="Greet"} 3`,
+		`meshrpc_errors_total{side="client",service="greeter.Service",method="Greet",status="503"} 1`,
+		`meshrpc_errors_total{side="client",service="greeter.Service",method="Greet",status="error"} 1`,
+		`meshrpc_errors_total{side="proxy",service="greeter",method="Greet",status="502"} 1`,
+		`meshrpc_errors_total{side="server",service="greeter.Service",method="Greet",status="400"} 1`,
+		`meshrpc_errors_total{side="server",service="greeter.Service",method="Greet",status="499"} 1`,
+		`meshrpc_request_duration_seconds_bucket{side="proxy",service="greeter",method="Greet",le="0.5"} 0`,
+		`meshrpc_request_duration_seconds_bucket{side="proxy",service="greeter",method="Greet",le="1"} 1`,
+		`meshrpc_request_duration_seconds_bucket{side="proxy",service="greeter",method="Greet",le="+Inf"} 2`,
+		`meshrpc_request_duration_seconds_sum{side="proxy",service="greeter",method="Greet"} 2.7`,
+		`meshrpc_request_duration_seconds_count{side="proxy",service="greeter",method="Greet"} 2`,
+		"# TYPE meshrpc_request_duration_seconds histogram",
+	} {
+		if !strings.Contains(out, line+"\n") {
+			t.Errorf("metrics don't contain %s", line)
+		}
+	}
+	// series are sorted by side, service and method
+	if client, server := strings.Index(out, `side="client"`), strings.Index(out, `side="server"`); client > server {
+		t.Error("series are not sorted")
+	}
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	if ct := rec.Header().Get("Content-Type"); ct != "text/plain; version=0.0.4" || rec.Body.String() != out {
+		t.Errorf("ServeHTTP() wrote %s of %d bytes, want the text format", ct, rec.Body.Len())
+	}
+}
+
+func TestQuoteLabel(t *testing.T) {
+	if got, want := quoteLabel("a\"b\\c\nd"), `"a\"b\\c\nd"`; got != want {
+		t.Errorf("quoteLabel() = %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,400 @@
+// Package outbox provides a durable client-side queue of RPC calls. Calls are persisted
+// in a local file log before they're sent, and retried with backoff until the remote
+// service accepts them, so pending calls survive restarts of the process.
+//
+// Delivery is at-least-once: a call that has been accepted right before a crash
+// may be sent again after the restart.
+package outbox
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/astranet/meshRPC/retry"
+)
+
+// ErrClosed is returned by Enqueue after the outbox has been closed.
+var ErrClosed = errors.New("outbox: closed")
+
+// HTTPClient sends calls to the remote service, e.g. cluster.Client.
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Call is a pending call of a remote method.
+type Call struct {
+	ID     uint64          `json:"id"`
+	Method string          `json:"method"`
+	Header http.Header     `json:"header,omitempty"`
+	Body   json.RawMessage `json:"body"`
+}
+
+// DefaultRetryPolicy delays retries of a call by 100ms at first, doubling up to 30s,
+// with a random fifth of the delay taken off.
+var DefaultRetryPolicy = &retry.Policy{
+	MinBackoff: 100 * time.Millisecond,
+	MaxBackoff: 30 * time.Second,
+	Jitter:     0.2,
+}
+
+type Options struct {
+	// RetryPolicy delays retries of a call with its backoff, DefaultRetryPolicy if not set.
+	// Only the backoff of the policy is used, since calls are retried until the service
+	// accepts them, upon network errors and 408, 429 or 5xx responses.
+	RetryPolicy *retry.Policy
+	// MinBackoff is the delay before the first retry of a call, it doubles with each
+	// next one up to MaxBackoff. They make a policy without jitter if RetryPolicy is not set.
+	//
+	// Deprecated: use RetryPolicy.
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+	// OnDrop is called when the service rejects a call with a status that isn't worth
+	// a retry, e.g. 400 Bad Request. By default dropped calls are logged.
+	OnDrop func(call *Call, err error)
+}
+
+func checkOptions(opt *Options) *Options {
+	if opt == nil {
+		opt = &Options{}
+	}
+	if opt.RetryPolicy == nil && (opt.MinBackoff > 0 || opt.MaxBackoff > 0) {
+		policy := &retry.Policy{
+			MinBackoff: opt.MinBackoff,
+			MaxBackoff: opt.MaxBackoff,
+		}
+		if policy.MinBackoff <= 0 {
+			policy.MinBackoff = DefaultRetryPolicy.MinBackoff
+		}
+		if policy.MaxBackoff < policy.MinBackoff {
+			policy.MaxBackoff = DefaultRetryPolicy.MaxBackoff
+		}
+		opt.RetryPolicy = policy
+	} else if opt.RetryPolicy == nil {
+		opt.RetryPolicy = DefaultRetryPolicy
+	}
+	if opt.OnDrop == nil {
+		opt.OnDrop = func(call *Call, err error) {
+			log.WithFields(log.Fields{
+				"layer":  "outbox",
+				"method": call.Method,
+				"id":     call.ID,
+			}).Warningln("call dropped:", err)
+		}
+	}
+	return opt
+}
+
+// Outbox is a durable queue of calls, that are delivered one by one in order of Enqueue.
+type Outbox struct {
+	opt    *Options
+	client HTTPClient
+	path   string
+
+	mux     sync.Mutex
+	file    *os.File
+	pending []*Call
+	lastID  uint64
+	closed  bool
+
+	wake   chan struct{}
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// New opens the file log at path, creating it if needed, and starts delivering
+// the calls that were left pending in it through the client.
+func New(path string, client HTTPClient, opt *Options) (*Outbox, error) {
+	o := &Outbox{
+		opt:    checkOptions(opt),
+		client: client,
+		path:   path,
+		wake:   make(chan struct{}, 1),
+	}
+	if err := o.load(); err != nil {
+		return nil, err
+	}
+	if err := o.compact(); err != nil {
+		return nil, err
+	}
+	o.ctx, o.cancel = context.WithCancel(context.Background())
+	o.wg.Add(1)
+	go o.deliverLoop()
+	return o, nil
+}
+
+// Enqueue persists the call of the remote method, it returns once the call is
+// written to the log. Body must be JSON.
+func (o *Outbox) Enqueue(method string, header http.Header, body []byte) error {
+	o.mux.Lock()
+	defer o.mux.Unlock()
+	if o.closed {
+		return ErrClosed
+	}
+	call := &Call{
+		ID:     o.lastID + 1,
+		Method: method,
+		Header: header,
+		Body:   body,
+	}
+	if err := o.write(&record{Call: call}, true); err != nil {
+		return err
+	}
+	o.lastID = call.ID
+	o.pending = append(o.pending, call)
+	select {
+	case o.wake <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// Pending returns the number of calls that haven't been accepted yet.
+func (o *Outbox) Pending() int {
+	o.mux.Lock()
+	defer o.mux.Unlock()
+	return len(o.pending)
+}
+
+// Close stops the delivery and closes the log, pending calls are delivered
+// next time the outbox is opened.
+func (o *Outbox) Close() error {
+	o.mux.Lock()
+	if o.closed {
+		o.mux.Unlock()
+		return nil
+	}
+	o.closed = true
+	o.mux.Unlock()
+
+	o.cancel()
+	o.wg.Wait()
+	return o.file.Close()
+}
+
+// record is a line of the log, it either adds a call or acknowledges one.
+type record struct {
+	Call *Call  `json:"call,omitempty"`
+	Ack  uint64 `json:"ack,omitempty"`
+}
+
+// load reads pending calls from the log. A torn record at the end of the log,
+// left by a crash in the middle of a write, is ignored.
+func (o *Outbox) load() error {
+	f, err := os.Open(o.path)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("outbox: failed to open log: %v", err)
+	}
+	defer f.Close()
+	var calls []*Call
+	acked := make(map[uint64]bool)
+	r := bufio.NewReader(f)
+	for {
+		line, err := r.ReadBytes('\n')
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return fmt.Errorf("outbox: failed to read log: %v", err)
+		}
+		var rec record
+		if err := json.Unmarshal(line, &rec); err != nil {
+			return fmt.Errorf("outbox: corrupted log %s: %v", o.path, err)
+		}
+		switch {
+		case rec.Call != nil:
+			calls = append(calls, rec.Call)
+			if rec.Call.ID > o.lastID {
+				o.lastID = rec.Call.ID
+			}
+		case rec.Ack > 0:
+			acked[rec.Ack] = true
+		}
+	}
+	for _, call := range calls {
+		if !acked[call.ID] {
+			o.pending = append(o.pending, call)
+		}
+	}
+	return nil
+}
+
+// compact rewrites the log with pending calls only, then opens it for appending.
+// The new log is synced before it replaces the old one, and the dir after that,
+// so a crash leaves either of them in place.
+func (o *Outbox) compact() error {
+	buf := new(bytes.Buffer)
+	enc := json.NewEncoder(buf)
+	for _, call := range o.pending {
+		if err := enc.Encode(&record{Call: call}); err != nil {
+			return fmt.Errorf("outbox: failed to encode call: %v", err)
+		}
+	}
+	tmpPath := o.path + ".tmp"
+	if err := writeSynced(tmpPath, buf.Bytes()); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("outbox: failed to write log: %v", err)
+	}
+	if err := os.Rename(tmpPath, o.path); err != nil {
+		return fmt.Errorf("outbox: failed to replace log: %v", err)
+	}
+	if err := syncDir(filepath.Dir(o.path)); err != nil {
+		return fmt.Errorf("outbox: failed to sync log dir: %v", err)
+	}
+	f, err := os.OpenFile(o.path, os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return fmt.Errorf("outbox: failed to open log: %v", err)
+	}
+	o.file = f
+	return nil
+}
+
+// writeSynced writes the data into a new file at path, and syncs it to the disk.
+func writeSynced(path string, data []byte) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// syncDir syncs the dir, so that renames of its files are on the disk.
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	if err := d.Sync(); err != nil {
+		d.Close()
+		return err
+	}
+	return d.Close()
+}
+
+// write appends the record to the log, the caller must hold the lock.
+func (o *Outbox) write(rec *record, sync bool) error {
+	data, err := json.Marshal(rec)
+	if err != nil {
+		return fmt.Errorf("outbox: failed to encode call: %v", err)
+	}
+	if _, err := o.file.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("outbox: failed to write log: %v", err)
+	}
+	if sync {
+		if err := o.file.Sync(); err != nil {
+			return fmt.Errorf("outbox: failed to sync log: %v", err)
+		}
+	}
+	return nil
+}
+
+func (o *Outbox) deliverLoop() {
+	defer o.wg.Done()
+	// failures of the current call, they make the backoff longer
+	var failures int
+	for {
+		o.mux.Lock()
+		var call *Call
+		if len(o.pending) > 0 {
+			call = o.pending[0]
+		}
+		o.mux.Unlock()
+		if call == nil {
+			select {
+			case <-o.wake:
+				continue
+			case <-o.ctx.Done():
+				return
+			}
+		}
+		status, err := o.deliver(call)
+		if err != nil && retryable(status) {
+			failures++
+			select {
+			case <-time.After(o.opt.RetryPolicy.Backoff(failures)):
+				continue
+			case <-o.ctx.Done():
+				return
+			}
+		}
+		failures = 0
+		if err != nil {
+			o.opt.OnDrop(call, err)
+		}
+		o.ack(call)
+	}
+}
+
+// deliver sends the call, the zero status stands for network errors.
+func (o *Outbox) deliver(call *Call) (int, error) {
+	req, err := http.NewRequest("POST", call.Method, bytes.NewReader(call.Body))
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+	req = req.WithContext(o.ctx)
+	for key, values := range call.Header {
+		req.Header[key] = append(req.Header[key], values...)
+	}
+	resp, err := o.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	respBody, _ := ioutil.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		err := fmt.Errorf("service error %d: %s", resp.StatusCode, string(respBody))
+		return resp.StatusCode, err
+	}
+	return resp.StatusCode, nil
+}
+
+// retryable reports whether a call that failed with the status may be accepted later.
+func retryable(status int) bool {
+	switch {
+	case status == 0, status >= 500,
+		status == http.StatusRequestTimeout, status == http.StatusTooManyRequests:
+		return true
+	default:
+		return false
+	}
+}
+
+// ack removes the call from the pending ones, the log is truncated once there are none.
+// Acks aren't synced, so a crash may cause the call to be delivered again.
+func (o *Outbox) ack(call *Call) {
+	o.mux.Lock()
+	defer o.mux.Unlock()
+	o.pending = o.pending[1:]
+	if len(o.pending) == 0 {
+		if err := o.file.Truncate(0); err == nil {
+			return
+		}
+	}
+	if err := o.write(&record{Ack: call.ID}, false); err != nil {
+		log.WithFields(log.Fields{
+			"layer": "outbox",
+		}).Warningln(err)
+	}
+}
@@ -0,0 +1,275 @@
+package outbox
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/astranet/meshRPC/retry"
+)
+
+// testClient answers calls with statuses returned by a func, and records delivered calls.
+type testClient struct {
+	status func(method string) int
+
+	mux       sync.Mutex
+	delivered []string
+	attempts  int
+}
+
+func (c *testClient) Do(req *http.Request) (*http.Response, error) {
+	body, _ := ioutil.ReadAll(req.Body)
+	c.mux.Lock()
+	c.attempts++
+	c.mux.Unlock()
+	status := c.status(req.URL.Path)
+	if status == 0 {
+		return nil, errors.New("connection refused")
+	}
+	if status == http.StatusOK {
+		c.mux.Lock()
+		c.delivered = append(c.delivered, req.URL.Path+" "+req.Header.Get("X-Test")+" "+string(body))
+		c.mux.Unlock()
+	}
+	return &http.Response{
+		StatusCode: status,
+		Body:       ioutil.NopCloser(strings.NewReader("")),
+	}, nil
+}
+
+func (c *testClient) Delivered() []string {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	return append([]string(nil), c.delivered...)
+}
+
+var testOptions = &Options{
+	RetryPolicy: &retry.Policy{
+		MinBackoff: time.Millisecond,
+		MaxBackoff: 5 * time.Millisecond,
+		Jitter:     0.2,
+	},
+}
+
+func testLogPath(t *testing.T) (string, func()) {
+	dir, err := ioutil.TempDir("", "outbox")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return filepath.Join(dir, "test.outbox"), func() { os.RemoveAll(dir) }
+}
+
+// waitPending waits until the outbox has no more than n pending calls.
+func waitPending(t *testing.T, o *Outbox, n int) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for o.Pending() > n {
+		if time.Now().After(deadline) {
+			t.Fatalf("%d calls still pending, want %d", o.Pending(), n)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestDeliver(t *testing.T) {
+	path, cleanup := testLogPath(t)
+	defer cleanup()
+	var failures int
+	var dropped []string
+	client := &testClient{status: func(method string) int {
+		switch method {
+		case "Flaky":
+			if failures++; failures == 1 {
+				return 0
+			} else if failures < 4 {
+				return http.StatusServiceUnavailable
+			}
+		case "Invalid":
+			return http.StatusBadRequest
+		}
+		return http.StatusOK
+	}}
+	opt := *testOptions
+	opt.OnDrop = func(call *Call, err error) {
+		dropped = append(dropped, call.Method)
+	}
+	o, err := New(path, client, &opt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer o.Close()
+	header := http.Header{"X-Test": {"yes"}}
+	for _, method := range []string{"Flaky", "Invalid", "Send"} {
+		if err := o.Enqueue(method, header, []byte(`{"n":1}`)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	waitPending(t, o, 0)
+
+	want := []string{`Flaky yes {"n":1}`, `Send yes {"n":1}`}
+	if got := client.Delivered(); strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("delivered %q, want %q", got, want)
+	}
+	if len(dropped) != 1 || dropped[0] != "Invalid" {
+		t.Errorf("dropped %q, want [Invalid]", dropped)
+	}
+	if client.attempts != 6 {
+		t.Errorf("made %d attempts, want 6", client.attempts)
+	}
+}
+
+func TestRestart(t *testing.T) {
+	path, cleanup := testLogPath(t)
+	defer cleanup()
+	down := &testClient{status: func(string) int { return http.StatusBadGateway }}
+	o, err := New(path, down, testOptions)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, method := range []string{"First", "Second"} {
+		if err := o.Enqueue(method, nil, []byte(`{}`)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := o.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := o.Enqueue("Third", nil, []byte(`{}`)); err != ErrClosed {
+		t.Errorf("Enqueue() after Close() = %v, want %v", err, ErrClosed)
+	}
+
+	// a crash in the middle of a write leaves a torn record
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.WriteString(`{"call":{"id":3,"meth`)
+	f.Close()
+
+	up := &testClient{status: func(string) int { return http.StatusOK }}
+	o, err = New(path, up, testOptions)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer o.Close()
+	if err := o.Enqueue("Third", nil, []byte(`{}`)); err != nil {
+		t.Fatal(err)
+	}
+	waitPending(t, o, 0)
+	want := []string{"First  {}", "Second  {}", "Third  {}"}
+	if got := up.Delivered(); strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("delivered %q, want %q", got, want)
+	}
+}
+
+func TestCompact(t *testing.T) {
+	path, cleanup := testLogPath(t)
+	defer cleanup()
+	down := &testClient{status: func(string) int { return http.StatusBadGateway }}
+	o, err := New(path, down, testOptions)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, method := range []string{"First", "Second", "Third"} {
+		if err := o.Enqueue(method, nil, []byte(`{}`)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	o.Close()
+	// acks of delivered calls are dropped by compaction
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.WriteString(`{"ack":1}` + "\n")
+	f.Close()
+
+	o, err = New(path, down, testOptions)
+	if err != nil {
+		t.Fatal(err)
+	}
+	o.Close()
+	if _, err := os.Stat(path + ".tmp"); !os.IsNotExist(err) {
+		t.Errorf("temp log left after compaction: %v", err)
+	}
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 2 || !strings.Contains(lines[0], `"Second"`) || !strings.Contains(lines[1], `"Third"`) {
+		t.Errorf("compacted log:\n%s\nwant the pending calls Second and Third only", data)
+	}
+}
+
+func TestCorruptedLog(t *testing.T) {
+	path, cleanup := testLogPath(t)
+	defer cleanup()
+	if err := ioutil.WriteFile(path, []byte("{\"call\":\n{}\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	client := &testClient{status: func(string) int { return http.StatusOK }}
+	if _, err := New(path, client, testOptions); err == nil {
+		t.Error("New() with a corrupted log succeeded")
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	path, cleanup := testLogPath(t)
+	defer cleanup()
+	var (
+		mux   sync.Mutex
+		times []time.Time
+	)
+	client := &testClient{status: func(string) int {
+		mux.Lock()
+		defer mux.Unlock()
+		if times = append(times, time.Now()); len(times) < 4 {
+			return http.StatusTooManyRequests
+		}
+		return http.StatusOK
+	}}
+	o, err := New(path, client, &Options{
+		RetryPolicy: &retry.Policy{
+			MinBackoff: 20 * time.Millisecond,
+			Jitter:     0.5,
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer o.Close()
+	if err := o.Enqueue("Send", nil, []byte(`{}`)); err != nil {
+		t.Fatal(err)
+	}
+	waitPending(t, o, 0)
+	mux.Lock()
+	defer mux.Unlock()
+	// backoffs of 20, 40 and 80ms, at least half of each is waited for
+	min := []time.Duration{10 * time.Millisecond, 20 * time.Millisecond, 40 * time.Millisecond}
+	for i := 1; i < len(times); i++ {
+		if d := times[i].Sub(times[i-1]); d < min[i-1] {
+			t.Errorf("retry %d after %v, want at least %v", i, d, min[i-1])
+		}
+	}
+}
+
+func TestDeprecatedBackoff(t *testing.T) {
+	opt := checkOptions(&Options{MinBackoff: time.Second})
+	if p := opt.RetryPolicy; p.MinBackoff != time.Second || p.MaxBackoff != 30*time.Second || p.Jitter != 0 {
+		t.Errorf("RetryPolicy = %+v, want backoff from 1s to 30s without jitter", p)
+	}
+	if opt := checkOptions(nil); opt.RetryPolicy != DefaultRetryPolicy {
+		t.Errorf("RetryPolicy = %+v, want DefaultRetryPolicy", opt.RetryPolicy)
+	}
+	policy := &retry.Policy{MinBackoff: time.Millisecond}
+	if opt := checkOptions(&Options{RetryPolicy: policy, MinBackoff: time.Second}); opt.RetryPolicy != policy {
+		t.Errorf("RetryPolicy = %+v, want the one of the options", opt.RetryPolicy)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"io/ioutil"
 	"log"
 	"os"
 	"path/filepath"
@@ -29,6 +30,30 @@ func (q Queue) Description() string {
 	return t.String()
 }
 
+// Diff returns a unified diff of all changes the queue would make to files,
+// and a flag whether there are any changes at all.
+func (q Queue) Diff() (string, bool) {
+	buf := new(bytes.Buffer)
+	var changed bool
+	for i, action := range q {
+		diff, err := action.Diff()
+		if err != nil {
+			log.Printf("Action#%d diff error: %v", i+1, err)
+			changed = true
+			continue
+		}
+		if len(diff) > 0 {
+			changed = true
+			buf.WriteString(diff)
+		}
+	}
+	return buf.String(), changed
+}
+
+// Exec runs all actions as a transaction. First, each action prepares its changes,
+// e.g. writes new contents into a temp file near the target. Then all prepared
+// changes are committed, e.g. temp files are renamed over the targets. Upon any
+// failure all actions are reverted in reverse order, restoring the prior state.
 func (q Queue) Exec() bool {
 	qq := make(Queue, 0, len(q))
 	revertPrevious := func(qq Queue) {
@@ -55,14 +80,28 @@ func (q Queue) Exec() bool {
 			return false
 		}
 	}
+	for i, action := range q {
+		if err := action.Commit(); err != nil {
+			log.Printf("Commit#%d error: %v", i+1, err)
+			revertPrevious(qq)
+			return false
+		}
+	}
 	return true
 }
 
 type QueueAction interface {
+	// Run prepares the action, it may return a file to be finalized.
 	Run() (*os.File, error)
 	Comment() string
+	// Finalize writes prepared changes into the file returned by Run.
 	Finalize(f *os.File) error
+	// Commit applies prepared changes, it's called once all actions have been prepared.
+	Commit() error
+	// Revert undoes both prepared and committed changes of the action.
 	Revert() error
+	// Diff returns a unified diff of changes the action would make, if any.
+	Diff() (string, error)
 }
 
 func CheckDirAction(path string) QueueAction {
@@ -82,63 +121,176 @@ func CheckDirAction(path string) QueueAction {
 }
 
 func NewDirAction(path string) QueueAction {
+	var created bool
 	return &queueAction{
 		action: func() (*os.File, error) {
-			err := os.MkdirAll(path, 0755)
-			return nil, err
+			if _, err := os.Stat(path); err == nil {
+				return nil, nil
+			}
+			if err := os.MkdirAll(path, 0755); err != nil {
+				return nil, err
+			}
+			created = true
+			return nil, nil
 		},
 		comment: fmt.Sprintf("new dir %s if not exists", projectPath(path)),
 		revert: func() error {
+			if !created {
+				return nil
+			}
 			return os.Remove(path)
 		},
 	}
 }
 
 func CreateNewFileAction(path string, contents []byte) QueueAction {
+	w := &fileWriter{
+		path:      path,
+		contents:  contents,
+		exclusive: true,
+	}
 	return &queueAction{
-		action: func() (f *os.File, err error) {
-			return os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
-		},
+		action: w.Prepare,
 		comment: fmt.Sprintf("new file %s with %d lines of content (no overwrite)",
 			projectPath(path), lineCount(contents)),
-		finalize: func(f *os.File) error {
-			if f == nil {
-				return nil
-			}
-			defer f.Close()
-			return flushBufferToFile(contents, f, filepath.Ext(path) == ".go")
-		},
-		revert: func() error {
-			return os.Remove(path)
+		finalize: w.Flush,
+		commit:   w.Commit,
+		revert:   w.Revert,
+		diff: func() (string, error) {
+			return fileDiff(path, contents)
 		},
 	}
 }
 
 func OverwriteFileAction(path string, contents []byte) QueueAction {
+	w := &fileWriter{
+		path:     path,
+		contents: contents,
+	}
 	return &queueAction{
-		action: func() (f *os.File, err error) {
-			return os.Create(path)
-		},
+		action: w.Prepare,
 		comment: fmt.Sprintf("overwrite file %s with %d lines of content",
 			projectPath(path), lineCount(contents)),
-		finalize: func(f *os.File) error {
-			if f == nil {
-				return nil
-			}
-			defer f.Close()
-			return flushBufferToFile(contents, f, filepath.Ext(path) == ".go")
-		},
-		revert: func() error {
-			return os.Remove(path)
+		finalize: w.Flush,
+		commit:   w.Commit,
+		revert:   w.Revert,
+		diff: func() (string, error) {
+			return fileDiff(path, contents)
 		},
 	}
 }
 
+// fileWriter writes new file contents into a temp file first, then renames it
+// over the target file. The original file is kept in memory, so it can be
+// restored if any other action of the queue fails.
+type fileWriter struct {
+	path      string
+	contents  []byte
+	exclusive bool
+
+	tmpPath   string
+	committed bool
+	existed   bool
+	backup    []byte
+	mode      os.FileMode
+}
+
+func (w *fileWriter) Prepare() (*os.File, error) {
+	if w.exclusive {
+		if _, err := os.Stat(w.path); err == nil {
+			return nil, fmt.Errorf("file %s already exists", w.path)
+		} else if !os.IsNotExist(err) {
+			return nil, err
+		}
+	}
+	f, err := ioutil.TempFile(filepath.Dir(w.path), "."+filepath.Base(w.path)+".tmp")
+	if err != nil {
+		return nil, err
+	}
+	w.tmpPath = f.Name()
+	return f, nil
+}
+
+func (w *fileWriter) Flush(f *os.File) error {
+	if f == nil {
+		return nil
+	}
+	err := flushBufferToFile(w.path, w.contents, f, filepath.Ext(w.path) == ".go")
+	if err == nil {
+		err = f.Sync()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+func (w *fileWriter) Commit() error {
+	w.mode = 0644
+	if info, err := os.Stat(w.path); err == nil {
+		if w.exclusive {
+			return fmt.Errorf("file %s already exists", w.path)
+		}
+		w.mode = info.Mode()
+		if w.backup, err = ioutil.ReadFile(w.path); err != nil {
+			return fmt.Errorf("failed to backup %s: %v", w.path, err)
+		}
+		w.existed = true
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+	if err := os.Chmod(w.tmpPath, w.mode); err != nil {
+		return err
+	}
+	if err := os.Rename(w.tmpPath, w.path); err != nil {
+		return err
+	}
+	w.committed = true
+	return nil
+}
+
+func (w *fileWriter) Revert() error {
+	if !w.committed {
+		if len(w.tmpPath) == 0 {
+			return nil
+		}
+		return os.Remove(w.tmpPath)
+	}
+	if !w.existed {
+		return os.Remove(w.path)
+	}
+	// restore the original contents atomically as well
+	f, err := ioutil.TempFile(filepath.Dir(w.path), "."+filepath.Base(w.path)+".bak")
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(w.backup)
+	if err == nil {
+		err = f.Sync()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Chmod(f.Name(), w.mode)
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), w.path)
+	}
+	if err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	return nil
+}
+
 type queueAction struct {
 	action   func() (*os.File, error)
 	comment  string
 	finalize func(f *os.File) error
+	commit   func() error
 	revert   func() error
+	diff     func() (string, error)
 }
 
 func (q *queueAction) Run() (*os.File, error) {
@@ -159,6 +311,13 @@ func (q *queueAction) Finalize(f *os.File) error {
 	return nil
 }
 
+func (q *queueAction) Commit() error {
+	if q.commit != nil {
+		return q.commit()
+	}
+	return nil
+}
+
 func (q *queueAction) Revert() error {
 	if q.revert != nil {
 		return q.revert()
@@ -166,6 +325,13 @@ func (q *queueAction) Revert() error {
 	return nil
 }
 
+func (q *queueAction) Diff() (string, error) {
+	if q.diff != nil {
+		return q.diff()
+	}
+	return "", nil
+}
+
 func lineCount(contents []byte) int {
 	var lines int
 	s := bufio.NewScanner(bytes.NewReader(contents))
@@ -179,15 +345,32 @@ func projectPath(path string) string {
 	return filepath.Join("[project]", strings.TrimPrefix(path, *projectDir))
 }
 
-func flushBufferToFile(buf []byte, f *os.File, fmt bool) error {
+// fileDiff returns a diff between the current file contents and the new ones,
+// formatted the same way as they would be flushed into the file.
+func fileDiff(path string, contents []byte) (string, error) {
+	oldContents, err := ioutil.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return "", err
+	}
+	if filepath.Ext(path) == ".go" {
+		if fmtBuf, err := imports.Process(path, contents, nil); err == nil {
+			contents = fmtBuf
+		}
+	}
+	name := projectPath(path)
+	return unifiedDiff(name, name, oldContents, contents), nil
+}
+
+// flushBufferToFile writes buf into f, formatting it as a Go source of the target path if requested.
+func flushBufferToFile(path string, buf []byte, f *os.File, fmt bool) error {
 	if fmt {
-		if fmtBuf, err := imports.Process(f.Name(), buf, nil); err == nil {
+		if fmtBuf, err := imports.Process(path, buf, nil); err == nil {
 			_, err = f.Write(fmtBuf)
 			return err
 		} else {
-			log.Printf("Warning: cannot gofmt %s: %s\n", f.Name(), err.Error())
-			f.Write(buf)
-			return nil
+			log.Printf("Warning: cannot gofmt %s: %s\n", path, err.Error())
+			_, err = f.Write(buf)
+			return err
 		}
 	}
 	_, err := f.Write(buf)
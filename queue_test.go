@@ -0,0 +1,144 @@
+package main
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func tempDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "meshrpc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func writeFile(t *testing.T, path, contents string, mode os.FileMode) {
+	if err := ioutil.WriteFile(path, []byte(contents), mode); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func checkFile(t *testing.T, path, contents string) {
+	t.Helper()
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Errorf("failed to read %s: %v", path, err)
+		return
+	}
+	if string(data) != contents {
+		t.Errorf("contents of %s = %q, want %q", path, data, contents)
+	}
+}
+
+func checkNoTempFiles(t *testing.T, dir string) {
+	t.Helper()
+	tmp, _ := filepath.Glob(filepath.Join(dir, ".*"))
+	if len(tmp) > 0 {
+		t.Errorf("temp files are left behind: %v", tmp)
+	}
+}
+
+// failingAction fails at the named stage of the queue.
+func failingAction(stage string) QueueAction {
+	fail := func() error {
+		return errors.New(stage + " failed")
+	}
+	a := &queueAction{
+		comment: "fail at " + stage,
+	}
+	switch stage {
+	case "run":
+		a.action = func() (*os.File, error) { return nil, fail() }
+	case "commit":
+		a.commit = fail
+	}
+	return a
+}
+
+func TestQueueExec(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+	existing := filepath.Join(dir, "existing.txt")
+	writeFile(t, existing, "old\n", 0600)
+	newDir := filepath.Join(dir, "client")
+	created := filepath.Join(newDir, "created.txt")
+
+	q := NewQueue(
+		CheckDirAction(dir),
+		OverwriteFileAction(existing, []byte("new\n")),
+		NewDirAction(newDir),
+		CreateNewFileAction(created, []byte("created\n")),
+	)
+	if !q.Exec() {
+		t.Fatal("Exec() failed")
+	}
+	checkFile(t, existing, "new\n")
+	checkFile(t, created, "created\n")
+	if info, err := os.Stat(existing); err != nil || info.Mode() != 0600 {
+		t.Errorf("mode of the overwritten file is not kept: %v", info.Mode())
+	}
+	checkNoTempFiles(t, dir)
+	checkNoTempFiles(t, newDir)
+
+	// new files are never overwritten
+	if NewQueue(CreateNewFileAction(created, []byte("again\n"))).Exec() {
+		t.Error("Exec() has overwritten an existing file")
+	}
+	checkFile(t, created, "created\n")
+	checkNoTempFiles(t, newDir)
+}
+
+func TestQueueRevert(t *testing.T) {
+	for _, stage := range []string{"run", "commit"} {
+		t.Run(stage, func(t *testing.T) {
+			dir := tempDir(t)
+			defer os.RemoveAll(dir)
+			existing := filepath.Join(dir, "existing.txt")
+			writeFile(t, existing, "old\n", 0600)
+			newDir := filepath.Join(dir, "client")
+			created := filepath.Join(newDir, "created.txt")
+
+			q := NewQueue(
+				OverwriteFileAction(existing, []byte("new\n")),
+				NewDirAction(newDir),
+				CreateNewFileAction(created, []byte("created\n")),
+				failingAction(stage),
+			)
+			if q.Exec() {
+				t.Fatal("Exec() succeeded")
+			}
+			checkFile(t, existing, "old\n")
+			if info, err := os.Stat(existing); err != nil || info.Mode() != 0600 {
+				t.Errorf("mode of the restored file is not kept: %v", info.Mode())
+			}
+			if _, err := os.Stat(newDir); !os.IsNotExist(err) {
+				t.Errorf("new dir is not removed: %v", err)
+			}
+			checkNoTempFiles(t, dir)
+		})
+	}
+}
+
+func TestQueueDiff(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+	existing := filepath.Join(dir, "existing.txt")
+	writeFile(t, existing, "same\n", 0644)
+
+	diff, changed := NewQueue(OverwriteFileAction(existing, []byte("same\n"))).Diff()
+	if changed || len(diff) > 0 {
+		t.Errorf("Diff() of the same contents = %q, %v", diff, changed)
+	}
+	diff, changed = NewQueue(
+		CheckDirAction(dir),
+		OverwriteFileAction(existing, []byte("changed\n")),
+	).Diff()
+	if !changed || len(diff) == 0 {
+		t.Errorf("Diff() of changed contents = %q, %v", diff, changed)
+	}
+	checkFile(t, existing, "same\n")
+}
@@ -0,0 +1,191 @@
+// Package retry defines retry policies of RPC calls, that are shared by generated clients
+// and cluster clients. A policy retries attempts that fail with transport errors or with
+// certain statuses, waiting for an exponential backoff with jitter between them, and
+// a budget keeps retries from multiplying the load of a service that is failing already.
+//
+//	client := greeter.NewServiceClient(greeterClient, &greeter.ServiceClientOptions{
+//		RetryPolicy: &retry.Policy{
+//			MaxAttempts: 4,
+//			MinBackoff:  50 * time.Millisecond,
+//			Jitter:      0.5,
+//			Budget:      retry.NewBudget(0.1, 20),
+//		},
+//	})
+package retry
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// DefaultPolicy makes up to 3 attempts, with a backoff of 100ms and then 200ms, up to a fifth
+// shorter, upon transport errors and 502, 503 or 504 responses.
+var DefaultPolicy = &Policy{
+	MaxAttempts: 3,
+	Jitter:      0.2,
+}
+
+// DefaultStatuses are retried unless the policy lists other ones.
+var DefaultStatuses = []int{
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// Policy tells which attempts are retried and when. The zero policy makes a single attempt.
+type Policy struct {
+	// MaxAttempts is the max number of attempts of a call, including the first one.
+	MaxAttempts int
+	// MinBackoff is the delay before the first retry, it doubles with each next one up to
+	// MaxBackoff. Defaults to 100ms and 5s.
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+	// Jitter is the fraction of the backoff that is random, so that clients that failed
+	// at once don't retry at once too, e.g. 0.2 makes backoffs 80-100% of their length.
+	Jitter float64
+	// Statuses are the response statuses to retry, DefaultStatuses if not set.
+	Statuses []int
+	// TransportError reports whether an attempt that failed with the transport error,
+	// i.e. without a response, is worth a retry. By default all of them are.
+	TransportError func(err error) bool
+	// Budget limits retries of all calls that share it, if set.
+	Budget *Budget
+}
+
+// WithAttempts returns a copy of the policy that makes up to n attempts, it shares the budget.
+func (p *Policy) WithAttempts(n int) *Policy {
+	cp := *p
+	cp.MaxAttempts = n
+	return &cp
+}
+
+// Do makes attempts of a call with fn until one succeeds, or fails in a way that is not
+// worth a retry, or the policy gives up. The status reported by fn is the status of the
+// response, the zero status stands for transport errors. Do returns the error of the
+// last attempt, it doesn't wait for the next one if ctx is done.
+func (p *Policy) Do(ctx context.Context, fn func() (status int, err error)) error {
+	if p.Budget != nil {
+		p.Budget.deposit()
+	}
+	for attempt := 1; ; attempt++ {
+		status, err := fn()
+		if err == nil || attempt >= p.MaxAttempts || !p.Retryable(status, err) {
+			return err
+		}
+		if p.Budget != nil && !p.Budget.withdraw() {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(p.Backoff(attempt)):
+		}
+	}
+}
+
+// Retryable reports whether an attempt that failed with the status, or with the transport
+// error if the status is zero, is worth a retry.
+func (p *Policy) Retryable(status int, err error) bool {
+	if status == 0 {
+		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			return false
+		}
+		return p.TransportError == nil || p.TransportError(err)
+	}
+	statuses := p.Statuses
+	if len(statuses) == 0 {
+		statuses = DefaultStatuses
+	}
+	for _, s := range statuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// Backoff returns the delay before the n-th retry, starting with 1.
+func (p *Policy) Backoff(n int) time.Duration {
+	minBackoff, maxBackoff := p.MinBackoff, p.MaxBackoff
+	if minBackoff <= 0 {
+		minBackoff = 100 * time.Millisecond
+	}
+	if maxBackoff <= 0 {
+		maxBackoff = 5 * time.Second
+	}
+	backoff := minBackoff
+	for i := 1; i < n && backoff < maxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > maxBackoff {
+		backoff = maxBackoff
+	}
+	if p.Jitter > 0 {
+		jitter := p.Jitter
+		if jitter > 1 {
+			jitter = 1
+		}
+		backoff -= time.Duration(rand.Float64() * jitter * float64(backoff))
+	}
+	return backoff
+}
+
+// Budget allows retries of up to a fraction of calls, on top of a reserve for the times
+// when there are few calls. Once the budget is spent, failed calls are not retried until
+// new calls earn it back. A budget is safe for concurrent use.
+type Budget struct {
+	ratio float64
+	// max is the reserve along with retries earned by budgetCalls calls
+	max float64
+
+	mux    sync.Mutex
+	tokens float64
+}
+
+// budgetCalls is the number of recent calls whose retries a budget saves up,
+// so a burst of failures after a quiet period doesn't retry all of them.
+const budgetCalls = 100
+
+// NewBudget returns a budget that allows ratio retries per call, e.g. 0.1 for a retry
+// per ten calls, and reserve retries regardless of the number of calls. Retries earned
+// by calls are saved up to those of the last 100 calls, and at least one.
+func NewBudget(ratio float64, reserve int) *Budget {
+	earned := ratio * budgetCalls
+	if earned < 1 {
+		earned = 1
+	}
+	return &Budget{
+		ratio:  ratio,
+		max:    float64(reserve) + earned,
+		tokens: float64(reserve),
+	}
+}
+
+// Available returns the number of retries that are allowed at the moment.
+func (b *Budget) Available() int {
+	b.mux.Lock()
+	defer b.mux.Unlock()
+	return int(b.tokens)
+}
+
+func (b *Budget) deposit() {
+	b.mux.Lock()
+	defer b.mux.Unlock()
+	if b.tokens += b.ratio; b.tokens > b.max {
+		b.tokens = b.max
+	}
+}
+
+func (b *Budget) withdraw() bool {
+	b.mux.Lock()
+	defer b.mux.Unlock()
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
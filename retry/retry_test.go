@@ -0,0 +1,172 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestBackoff(t *testing.T) {
+	p := &Policy{MinBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+	tests := []struct {
+		n    int
+		want time.Duration
+	}{
+		{1, 100 * time.Millisecond},
+		{2, 200 * time.Millisecond},
+		{4, 800 * time.Millisecond},
+		{5, time.Second},
+		{9, time.Second},
+	}
+	for _, tt := range tests {
+		if got := p.Backoff(tt.n); got != tt.want {
+			t.Errorf("Backoff(%d) = %v, want %v", tt.n, got, tt.want)
+		}
+	}
+	if got := (&Policy{}).Backoff(1); got != 100*time.Millisecond {
+		t.Errorf("Backoff(1) of the zero policy = %v, want 100ms", got)
+	}
+
+	p.Jitter = 0.2
+	for i := 0; i < 100; i++ {
+		if got := p.Backoff(2); got <= 160*time.Millisecond || got > 200*time.Millisecond {
+			t.Fatalf("Backoff(2) with jitter = %v, want 160-200ms", got)
+		}
+	}
+}
+
+func TestRetryable(t *testing.T) {
+	p := &Policy{}
+	tests := []struct {
+		status int
+		err    error
+		want   bool
+	}{
+		{0, errors.New("connection refused"), true},
+		{0, context.Canceled, false},
+		{0, fmt.Errorf("post: %w", context.DeadlineExceeded), false},
+		{http.StatusServiceUnavailable, nil, true},
+		{http.StatusInternalServerError, nil, false},
+		{http.StatusBadRequest, nil, false},
+	}
+	for _, tt := range tests {
+		if got := p.Retryable(tt.status, tt.err); got != tt.want {
+			t.Errorf("Retryable(%d, %v) = %v, want %v", tt.status, tt.err, got, tt.want)
+		}
+	}
+
+	p = &Policy{
+		Statuses:       []int{http.StatusTooManyRequests},
+		TransportError: func(err error) bool { return false },
+	}
+	if p.Retryable(http.StatusServiceUnavailable, nil) || !p.Retryable(http.StatusTooManyRequests, nil) {
+		t.Error("Retryable() doesn't follow the statuses of the policy")
+	}
+	if p.Retryable(0, errors.New("connection refused")) {
+		t.Error("Retryable() doesn't follow TransportError of the policy")
+	}
+}
+
+func TestDo(t *testing.T) {
+	p := &Policy{MaxAttempts: 3, MinBackoff: time.Millisecond}
+	errUnavailable := errors.New("unavailable")
+	var attempts int
+	err := p.Do(context.Background(), func() (int, error) {
+		attempts++
+		return http.StatusServiceUnavailable, errUnavailable
+	})
+	if err != errUnavailable || attempts != 3 {
+		t.Errorf("Do() = %v after %d attempts, want the last error after 3", err, attempts)
+	}
+
+	attempts = 0
+	err = p.Do(context.Background(), func() (int, error) {
+		if attempts++; attempts < 2 {
+			return 0, errUnavailable
+		}
+		return http.StatusOK, nil
+	})
+	if err != nil || attempts != 2 {
+		t.Errorf("Do() = %v after %d attempts, want success after 2", err, attempts)
+	}
+
+	attempts = 0
+	p.Do(context.Background(), func() (int, error) {
+		attempts++
+		return http.StatusBadRequest, errors.New("bad request")
+	})
+	if attempts != 1 {
+		t.Errorf("Do() made %d attempts of a call that is not worth a retry, want 1", attempts)
+	}
+}
+
+func TestDoContextDone(t *testing.T) {
+	p := &Policy{MaxAttempts: 3, MinBackoff: time.Hour}
+	ctx, cancel := context.WithCancel(context.Background())
+	var attempts int
+	done := make(chan error)
+	go func() {
+		done <- p.Do(ctx, func() (int, error) {
+			attempts++
+			return http.StatusServiceUnavailable, errors.New("unavailable")
+		})
+	}()
+	cancel()
+	select {
+	case err := <-done:
+		if err == nil || attempts != 1 {
+			t.Errorf("Do() = %v after %d attempts, want an error after 1", err, attempts)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Do() waits for the backoff after ctx is done")
+	}
+}
+
+func TestBudget(t *testing.T) {
+	b := NewBudget(0.5, 2)
+	if n := b.Available(); n != 2 {
+		t.Fatalf("Available() = %d, want the reserve of 2", n)
+	}
+	if !b.withdraw() || !b.withdraw() || b.withdraw() {
+		t.Fatal("withdraw() doesn't stop after the reserve is spent")
+	}
+	b.deposit()
+	if n := b.Available(); n != 0 {
+		t.Errorf("Available() = %d after a call, want 0", n)
+	}
+	b.deposit()
+	if n := b.Available(); n != 1 {
+		t.Errorf("Available() = %d after two calls, want 1", n)
+	}
+	for i := 0; i < 1000; i++ {
+		b.deposit()
+	}
+	if n := b.Available(); n != 52 {
+		t.Errorf("Available() = %d after many calls, want the reserve of 2 and 50 earned by 100 calls", n)
+	}
+
+	// retries are earned by calls without a reserve too
+	b = NewBudget(0.1, 0)
+	if b.withdraw() {
+		t.Error("withdraw() of an empty budget succeeded")
+	}
+	for i := 0; i < 25; i++ {
+		b.deposit()
+	}
+	if !b.withdraw() || !b.withdraw() || b.withdraw() {
+		t.Error("withdraw() doesn't allow 2 retries earned by 25 calls")
+	}
+
+	p := &Policy{MaxAttempts: 5, MinBackoff: time.Millisecond, Budget: NewBudget(0, 1)}
+	var attempts int
+	p.Do(context.Background(), func() (int, error) {
+		attempts++
+		return 0, errors.New("connection refused")
+	})
+	if attempts != 2 {
+		t.Errorf("Do() made %d attempts with a budget of a retry, want 2", attempts)
+	}
+}
@@ -0,0 +1,106 @@
+package trace
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Exporter receives finished spans, e.g. to send them to a tracing backend.
+// ExportSpan is called by the goroutine that finishes the span, so it must be
+// safe for concurrent use and should not block for long.
+type Exporter interface {
+	ExportSpan(span *Span) error
+}
+
+var (
+	exporterMux sync.RWMutex
+	exporter    Exporter
+)
+
+// SetExporter sets the exporter of finished spans, nil disables the export.
+func SetExporter(e Exporter) {
+	exporterMux.Lock()
+	exporter = e
+	exporterMux.Unlock()
+}
+
+func getExporter() Exporter {
+	exporterMux.RLock()
+	defer exporterMux.RUnlock()
+	return exporter
+}
+
+// NewStdoutExporter returns an exporter that prints a line per span to stdout.
+func NewStdoutExporter() Exporter {
+	return &textExporter{
+		w: os.Stdout,
+	}
+}
+
+type textExporter struct {
+	mux sync.Mutex
+	w   io.Writer
+}
+
+func (e *textExporter) ExportSpan(span *Span) error {
+	line := fmt.Sprintf("trace=%s span=%s", span.TraceID, span.SpanID)
+	if span.ParentID != nil {
+		line += fmt.Sprintf(" parent=%s", span.ParentID)
+	}
+	line += fmt.Sprintf(" %s %s took=%v", span.Kind, span.Name, span.Duration().Round(time.Microsecond))
+	if span.Status > 0 {
+		line += fmt.Sprintf(" status=%d", span.Status)
+	}
+	keys := make([]string, 0, len(span.Attributes))
+	for key := range span.Attributes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		line += fmt.Sprintf(" %s=%q", key, span.Attributes[key])
+	}
+	if len(span.Error) > 0 {
+		line += fmt.Sprintf(" error=%q", span.Error)
+	}
+	e.mux.Lock()
+	defer e.mux.Unlock()
+	_, err := io.WriteString(e.w, line+"\n")
+	return err
+}
+
+// FileExporter appends spans to a file as JSON lines.
+type FileExporter struct {
+	mux  sync.Mutex
+	file *os.File
+	enc  *json.Encoder
+}
+
+// NewFileExporter opens the file at path for appending, creating it if needed.
+func NewFileExporter(path string) (*FileExporter, error) {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("trace: failed to open file: %v", err)
+	}
+	return &FileExporter{
+		file: f,
+		enc:  json.NewEncoder(f),
+	}, nil
+}
+
+func (e *FileExporter) ExportSpan(span *Span) error {
+	e.mux.Lock()
+	defer e.mux.Unlock()
+	return e.enc.Encode(span)
+}
+
+// Close closes the file, spans exported afterwards fail.
+func (e *FileExporter) Close() error {
+	e.mux.Lock()
+	defer e.mux.Unlock()
+	return e.file.Close()
+}
@@ -0,0 +1,84 @@
+package trace
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func testSpan() *Span {
+	parent := SpanID{3}
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	return &Span{
+		Name:     "greeter.Service/Greet",
+		Kind:     KindServer,
+		TraceID:  TraceID{1},
+		SpanID:   SpanID{2},
+		ParentID: &parent,
+		Start:    start,
+		End:      start.Add(1500 * time.Microsecond),
+		Status:   400,
+		Error:    "invalid name",
+		Attributes: map[string]string{
+			"b": "2",
+			"a": "1",
+		},
+	}
+}
+
+func TestTextExporter(t *testing.T) {
+	buf := new(bytes.Buffer)
+	e := &textExporter{w: buf}
+	if err := e.ExportSpan(testSpan()); err != nil {
+		t.Fatal(err)
+	}
+	want := "trace=01000000000000000000000000000000 span=0200000000000000 parent=0300000000000000" +
+		` server greeter.Service/Greet took=1.5ms status=400 a="1" b="2" error="invalid name"` + "\n"
+	if buf.String() != want {
+		t.Errorf("exported %q, want %q", buf.String(), want)
+	}
+}
+
+func TestFileExporter(t *testing.T) {
+	dir, err := ioutil.TempDir("", "trace")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "spans.json")
+	e, err := NewFileExporter(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 2; i++ {
+		if err := e.ExportSpan(testSpan()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := e.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := e.ExportSpan(testSpan()); err == nil {
+		t.Error("ExportSpan() after Close() succeeded")
+	}
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := bytes.Split(bytes.TrimSpace(data), []byte("\n"))
+	if len(lines) != 2 {
+		t.Fatalf("got %d lines, want 2", len(lines))
+	}
+	var span map[string]interface{}
+	if err := json.Unmarshal(lines[1], &span); err != nil {
+		t.Fatal(err)
+	}
+	if span["trace_id"] != "01000000000000000000000000000000" || span["parent_id"] != "0300000000000000" ||
+		span["status"] != 400.0 {
+		t.Errorf("exported span %v", span)
+	}
+}
@@ -0,0 +1,269 @@
+// Package trace records spans of RPC calls and propagates them across services with
+// the W3C Trace Context headers, traceparent and tracestate. Generated handlers and
+// clients start spans of each call, and so does the cluster reverse proxy, finished
+// spans are passed to the exporter set by SetExporter:
+//
+//	trace.SetExporter(trace.NewStdoutExporter())
+//
+// Spans are propagated even if there is no exporter, so services that don't export
+// them still link the spans of their callers and callees into a single trace.
+package trace
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// ParentHeader carries the trace ID and the span ID of the caller.
+	ParentHeader = "traceparent"
+	// StateHeader carries vendor-specific trace state, it's passed on as is.
+	StateHeader = "tracestate"
+)
+
+// Kind tells the role of the span in the call.
+type Kind string
+
+const (
+	KindServer Kind = "server"
+	KindClient Kind = "client"
+	KindProxy  Kind = "proxy"
+)
+
+// TraceID identifies the trace, that is shared by all spans of a request.
+type TraceID [16]byte
+
+func (id TraceID) String() string {
+	return hex.EncodeToString(id[:])
+}
+
+func (id TraceID) MarshalText() ([]byte, error) {
+	return []byte(id.String()), nil
+}
+
+// SpanID identifies the span within the trace.
+type SpanID [8]byte
+
+func (id SpanID) String() string {
+	return hex.EncodeToString(id[:])
+}
+
+func (id SpanID) MarshalText() ([]byte, error) {
+	return []byte(id.String()), nil
+}
+
+// SpanContext is the part of a span that is propagated to its children.
+type SpanContext struct {
+	TraceID TraceID
+	SpanID  SpanID
+	Sampled bool
+	State   string
+}
+
+// IsValid reports whether both IDs are set.
+func (sc SpanContext) IsValid() bool {
+	return sc.TraceID != TraceID{} && sc.SpanID != SpanID{}
+}
+
+// Inject sets the traceparent and tracestate headers of the span context.
+func (sc SpanContext) Inject(header http.Header) {
+	flags := "00"
+	if sc.Sampled {
+		flags = "01"
+	}
+	header.Set(ParentHeader, fmt.Sprintf("00-%s-%s-%s", sc.TraceID, sc.SpanID, flags))
+	if len(sc.State) > 0 {
+		header.Set(StateHeader, sc.State)
+	} else {
+		header.Del(StateHeader)
+	}
+}
+
+// Extract reads the span context from the traceparent and tracestate headers,
+// it returns false if there is none or it's malformed.
+func Extract(header http.Header) (SpanContext, bool) {
+	sc, err := ParseParent(header.Get(ParentHeader))
+	if err != nil {
+		return SpanContext{}, false
+	}
+	sc.State = strings.Join(header[http.CanonicalHeaderKey(StateHeader)], ",")
+	return sc, true
+}
+
+var errInvalidParent = errors.New("trace: invalid traceparent")
+
+// ParseParent parses the value of the traceparent header.
+func ParseParent(v string) (SpanContext, error) {
+	var sc SpanContext
+	parts := strings.Split(strings.TrimSpace(v), "-")
+	if len(parts) < 4 {
+		return sc, errInvalidParent
+	}
+	version, traceID, spanID, flags := parts[0], parts[1], parts[2], parts[3]
+	if len(version) != 2 || version == "ff" || (version == "00" && len(parts) != 4) {
+		return sc, errInvalidParent
+	}
+	if len(traceID) != 32 || len(spanID) != 16 || len(flags) != 2 {
+		return sc, errInvalidParent
+	}
+	if _, err := hex.Decode(sc.TraceID[:], []byte(traceID)); err != nil {
+		return sc, errInvalidParent
+	}
+	if _, err := hex.Decode(sc.SpanID[:], []byte(spanID)); err != nil {
+		return sc, errInvalidParent
+	}
+	var flagBits [1]byte
+	if _, err := hex.Decode(flagBits[:], []byte(flags)); err != nil {
+		return sc, errInvalidParent
+	}
+	if !sc.IsValid() {
+		return sc, errInvalidParent
+	}
+	sc.Sampled = flagBits[0]&1 == 1
+	return sc, nil
+}
+
+// Span is a timed operation of a trace, e.g. a call of a service method.
+type Span struct {
+	Name     string    `json:"name"`
+	Kind     Kind      `json:"kind"`
+	TraceID  TraceID   `json:"trace_id"`
+	SpanID   SpanID    `json:"span_id"`
+	ParentID *SpanID   `json:"parent_id,omitempty"`
+	Start    time.Time `json:"start"`
+	End      time.Time `json:"end"`
+	// Status is the HTTP status of the call, if known.
+	Status     int               `json:"status,omitempty"`
+	Error      string            `json:"error,omitempty"`
+	Attributes map[string]string `json:"attributes,omitempty"`
+
+	sampled bool
+	state   string
+	mux     sync.Mutex
+	ended   bool
+}
+
+// Start starts a span of the named operation. It's a child of the remote span found
+// in header, if any, e.g. in handlers, otherwise of the span of ctx, otherwise
+// it starts a new trace. The returned context carries the span.
+func Start(ctx context.Context, name string, kind Kind, header http.Header) (context.Context, *Span) {
+	span := &Span{
+		Name:   name,
+		Kind:   kind,
+		SpanID: newSpanID(),
+		Start:  time.Now(),
+	}
+	parent, ok := Extract(header)
+	if !ok {
+		if s := FromContext(ctx); s != nil {
+			parent, ok = s.Context(), true
+		}
+	}
+	if ok {
+		span.TraceID = parent.TraceID
+		span.ParentID = &parent.SpanID
+		span.sampled = parent.Sampled
+		span.state = parent.State
+	} else {
+		span.TraceID = newTraceID()
+		span.sampled = true
+	}
+	return NewContext(ctx, span), span
+}
+
+// Context returns the span context, that children of the span inherit.
+func (s *Span) Context() SpanContext {
+	return SpanContext{
+		TraceID: s.TraceID,
+		SpanID:  s.SpanID,
+		Sampled: s.sampled,
+		State:   s.state,
+	}
+}
+
+// Inject sets the traceparent and tracestate headers, so the receiver of the request
+// continues the trace as a child of the span.
+func (s *Span) Inject(header http.Header) {
+	s.Context().Inject(header)
+}
+
+// SetAttribute annotates the span.
+func (s *Span) SetAttribute(key, value string) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	if s.Attributes == nil {
+		s.Attributes = make(map[string]string)
+	}
+	s.Attributes[key] = value
+}
+
+// SetStatus records the HTTP status of the call.
+func (s *Span) SetStatus(status int) {
+	s.mux.Lock()
+	s.Status = status
+	s.mux.Unlock()
+}
+
+// Finish ends the span with the error of the operation, if any, and exports it.
+// Only the first call has effect.
+func (s *Span) Finish(err error) {
+	s.mux.Lock()
+	if s.ended {
+		s.mux.Unlock()
+		return
+	}
+	s.ended = true
+	s.End = time.Now()
+	if err != nil {
+		s.Error = err.Error()
+	}
+	s.mux.Unlock()
+	if !s.sampled {
+		return
+	}
+	if e := getExporter(); e != nil {
+		if err := e.ExportSpan(s); err != nil {
+			log.Printf("trace: failed to export span: %v", err)
+		}
+	}
+}
+
+// Duration returns the duration of the finished span.
+func (s *Span) Duration() time.Duration {
+	return s.End.Sub(s.Start)
+}
+
+type spanKey struct{}
+
+// NewContext returns a copy of ctx that carries the span.
+func NewContext(ctx context.Context, span *Span) context.Context {
+	return context.WithValue(ctx, spanKey{}, span)
+}
+
+// FromContext returns the span of ctx, or nil if there is none.
+func FromContext(ctx context.Context) *Span {
+	span, _ := ctx.Value(spanKey{}).(*Span)
+	return span
+}
+
+func newTraceID() (id TraceID) {
+	for id == (TraceID{}) {
+		rand.Read(id[:])
+	}
+	return id
+}
+
+func newSpanID() (id SpanID) {
+	for id == (SpanID{}) {
+		rand.Read(id[:])
+	}
+	return id
+}
@@ -0,0 +1,131 @@
+package trace
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+)
+
+const (
+	testTraceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	testSpanID  = "00f067aa0ba902b7"
+)
+
+func TestParseParent(t *testing.T) {
+	tests := []struct {
+		value   string
+		valid   bool
+		sampled bool
+	}{
+		{"00-" + testTraceID + "-" + testSpanID + "-01", true, true},
+		{" 00-" + testTraceID + "-" + testSpanID + "-00 ", true, false},
+		{"00-" + testTraceID + "-" + testSpanID + "-03", true, true},
+		// future versions may have more fields
+		{"01-" + testTraceID + "-" + testSpanID + "-01-extra", true, true},
+		{"00-" + testTraceID + "-" + testSpanID + "-01-extra", false, false},
+		{"ff-" + testTraceID + "-" + testSpanID + "-01", false, false},
+		{"00-00000000000000000000000000000000-" + testSpanID + "-01", false, false},
+		{"00-" + testTraceID + "-0000000000000000-01", false, false},
+		{"00-" + testTraceID + "-" + testSpanID + "-0x", false, false},
+		{"00-" + testTraceID[:31] + "z-" + testSpanID + "-01", false, false},
+		{"00-" + testTraceID + "-" + testSpanID[:15] + "-01", false, false},
+		{"00-" + testTraceID + "-" + testSpanID, false, false},
+		{"", false, false},
+	}
+	for _, tt := range tests {
+		sc, err := ParseParent(tt.value)
+		if (err == nil) != tt.valid {
+			t.Errorf("ParseParent(%q) error = %v, want valid %v", tt.value, err, tt.valid)
+			continue
+		}
+		if !tt.valid {
+			continue
+		}
+		if sc.TraceID.String() != testTraceID || sc.SpanID.String() != testSpanID || sc.Sampled != tt.sampled {
+			t.Errorf("ParseParent(%q) = %+v, want sampled %v", tt.value, sc, tt.sampled)
+		}
+	}
+}
+
+func TestInjectExtract(t *testing.T) {
+	sc, err := ParseParent("00-" + testTraceID + "-" + testSpanID + "-01")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sc.State = "vendor=1"
+	header := http.Header{}
+	sc.Inject(header)
+	if v := header.Get(ParentHeader); v != "00-"+testTraceID+"-"+testSpanID+"-01" {
+		t.Errorf("traceparent = %q", v)
+	}
+	header.Add(StateHeader, "other=2")
+	got, ok := Extract(header)
+	if want := "vendor=1,other=2"; !ok || got.State != want {
+		t.Errorf("Extract() = %+v, %v, want state %q", got, ok, want)
+	}
+
+	sc.State = ""
+	sc.Sampled = false
+	sc.Inject(header)
+	if got, ok := Extract(header); !ok || got != sc {
+		t.Errorf("Extract() = %+v, %v, want %+v", got, ok, sc)
+	}
+	if _, ok := Extract(http.Header{ParentHeader: {"garbage"}}); ok {
+		t.Error("Extract() of a malformed traceparent succeeded")
+	}
+}
+
+// testExporter keeps exported spans.
+type testExporter struct {
+	mux   sync.Mutex
+	spans []*Span
+}
+
+func (e *testExporter) ExportSpan(span *Span) error {
+	e.mux.Lock()
+	defer e.mux.Unlock()
+	e.spans = append(e.spans, span)
+	return nil
+}
+
+func TestStart(t *testing.T) {
+	exporter := &testExporter{}
+	SetExporter(exporter)
+	defer SetExporter(nil)
+
+	ctx, root := Start(context.Background(), "root", KindClient, nil)
+	if root.ParentID != nil || !root.Context().IsValid() || FromContext(ctx) != root {
+		t.Fatalf("root span %+v, want a new trace carried by the context", root)
+	}
+	_, child := Start(ctx, "child", KindClient, http.Header{})
+	if child.TraceID != root.TraceID || child.ParentID == nil || *child.ParentID != root.SpanID {
+		t.Errorf("child of the context span %+v, want the trace of %+v", child, root)
+	}
+
+	header := http.Header{}
+	remote := SpanContext{TraceID: TraceID{1}, SpanID: SpanID{2}, State: "vendor=1"}
+	remote.Inject(header)
+	_, server := Start(ctx, "server", KindServer, header)
+	if server.TraceID != remote.TraceID || *server.ParentID != remote.SpanID || server.Context().State != "vendor=1" {
+		t.Errorf("child of the remote span %+v, want the trace of %+v", server, remote)
+	}
+
+	child.SetStatus(http.StatusBadGateway)
+	child.SetAttribute("http.method", "POST")
+	child.Finish(errors.New("bad gateway"))
+	child.Finish(nil)
+	// the remote span is not sampled, so its children are not exported
+	server.Finish(nil)
+	root.Finish(nil)
+	if len(exporter.spans) != 2 || exporter.spans[0] != child || exporter.spans[1] != root {
+		t.Fatalf("exported %d spans, want the child and the root", len(exporter.spans))
+	}
+	if child.Error != "bad gateway" || child.Status != http.StatusBadGateway || child.Attributes["http.method"] != "POST" {
+		t.Errorf("child span %+v, want the status, the error and the attribute", child)
+	}
+	if child.Duration() < 0 || child.End.IsZero() {
+		t.Errorf("child span took %v, want it finished", child.Duration())
+	}
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+const diffContextLines = 3
+
+// unifiedDiff returns a unified diff between old and new contents of the file,
+// the result is empty when contents are equal.
+func unifiedDiff(oldName, newName string, oldContents, newContents []byte) string {
+	if bytes.Equal(oldContents, newContents) {
+		return ""
+	}
+	a := splitLines(oldContents)
+	b := splitLines(newContents)
+	ops := diffLines(a, b)
+
+	buf := new(bytes.Buffer)
+	fmt.Fprintf(buf, "--- %s\n+++ %s\n", oldName, newName)
+	for start := 0; start < len(ops); {
+		// find the next change
+		for start < len(ops) && ops[start].kind == ' ' {
+			start++
+		}
+		if start == len(ops) {
+			break
+		}
+		hunkStart := start - diffContextLines
+		if hunkStart < 0 {
+			hunkStart = 0
+		}
+		// extend the hunk while changes are close enough to each other
+		end := start
+		for end < len(ops) {
+			if ops[end].kind != ' ' {
+				end++
+				continue
+			}
+			next := end
+			for next < len(ops) && ops[next].kind == ' ' {
+				next++
+			}
+			if next == len(ops) || next-end > 2*diffContextLines {
+				break
+			}
+			end = next
+		}
+		hunkEnd := end + diffContextLines
+		if hunkEnd > len(ops) {
+			hunkEnd = len(ops)
+		}
+		writeHunk(buf, ops[hunkStart:hunkEnd])
+		start = hunkEnd
+	}
+	return buf.String()
+}
+
+type diffOp struct {
+	kind byte // ' ', '-' or '+'
+	line string
+	// 1-based line numbers in old and new contents
+	oldLine int
+	newLine int
+}
+
+func writeHunk(buf *bytes.Buffer, ops []diffOp) {
+	var oldStart, newStart, oldCount, newCount int
+	for _, op := range ops {
+		if op.kind != '+' {
+			if oldCount == 0 {
+				oldStart = op.oldLine
+			}
+			oldCount++
+		}
+		if op.kind != '-' {
+			if newCount == 0 {
+				newStart = op.newLine
+			}
+			newCount++
+		}
+	}
+	if oldCount == 0 {
+		oldStart = ops[0].oldLine - 1
+	}
+	if newCount == 0 {
+		newStart = ops[0].newLine - 1
+	}
+	fmt.Fprintf(buf, "@@ -%d,%d +%d,%d @@\n", oldStart, oldCount, newStart, newCount)
+	for _, op := range ops {
+		buf.WriteByte(op.kind)
+		buf.WriteString(op.line)
+		buf.WriteByte('\n')
+	}
+}
+
+// diffLines computes the shortest edit script using the longest common subsequence.
+func diffLines(a, b []string) []diffOp {
+	n, m := len(a), len(b)
+	lcs := make([][]int, n+1)
+	for i := range lcs {
+		lcs[i] = make([]int, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+	ops := make([]diffOp, 0, n+m)
+	i, j := 0, 0
+	for i < n || j < m {
+		switch {
+		case i < n && j < m && a[i] == b[j]:
+			ops = append(ops, diffOp{kind: ' ', line: a[i], oldLine: i + 1, newLine: j + 1})
+			i++
+			j++
+		case j < m && (i == n || lcs[i][j+1] > lcs[i+1][j]):
+			ops = append(ops, diffOp{kind: '+', line: b[j], oldLine: i + 1, newLine: j + 1})
+			j++
+		default:
+			ops = append(ops, diffOp{kind: '-', line: a[i], oldLine: i + 1, newLine: j + 1})
+			i++
+		}
+	}
+	return ops
+}
+
+func splitLines(contents []byte) []string {
+	if len(contents) == 0 {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(string(contents), "\n"), "\n")
+}
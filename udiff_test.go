@@ -0,0 +1,52 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestUnifiedDiff(t *testing.T) {
+	tests := []struct {
+		name string
+		old  string
+		new  string
+		want string
+	}{{
+		name: "equal",
+		old:  "a\nb\n",
+		new:  "a\nb\n",
+		want: "",
+	}, {
+		name: "new file",
+		old:  "",
+		new:  "a\nb\n",
+		want: "--- old\n+++ new\n@@ -0,0 +1,2 @@\n+a\n+b\n",
+	}, {
+		name: "changed line with context",
+		old:  "1\n2\n3\n4\n5\n6\n7\n8\n9\n",
+		new:  "1\n2\n3\n4\nfive\n6\n7\n8\n9\n",
+		want: "--- old\n+++ new\n@@ -2,7 +2,7 @@\n 2\n 3\n 4\n-5\n+five\n 6\n 7\n 8\n",
+	}, {
+		name: "removed line at the end",
+		old:  "1\n2\n3\n",
+		new:  "1\n2\n",
+		want: "--- old\n+++ new\n@@ -1,3 +1,2 @@\n 1\n 2\n-3\n",
+	}, {
+		name: "close changes share a hunk",
+		old:  "1\n2\n3\n4\n5\n6\n7\n8\n",
+		new:  "one\n2\n3\n4\n5\n6\n7\neight\n",
+		want: "--- old\n+++ new\n@@ -1,8 +1,8 @@\n-1\n+one\n 2\n 3\n 4\n 5\n 6\n 7\n-8\n+eight\n",
+	}, {
+		name: "distant changes get own hunks",
+		old:  "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n",
+		new:  "one\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\ntwelve\n",
+		want: "--- old\n+++ new\n@@ -1,4 +1,4 @@\n-1\n+one\n 2\n 3\n 4\n@@ -9,4 +9,4 @@\n 9\n 10\n 11\n-12\n+twelve\n",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := unifiedDiff("old", "new", []byte(tt.old), []byte(tt.new))
+			if got != tt.want {
+				t.Errorf("unifiedDiff() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,317 @@
+// Package validate checks values against rules of their `validate` struct tags,
+// e.g. `validate:"required,max=64"`, and calls their Validate() error methods.
+// Generated RPC handlers use it to validate requests before calling the service.
+//
+// Supported rules:
+//
+//	required    the value is not zero, slices and maps are not empty
+//	omitempty   skips other rules if the value is zero
+//	min=N       strings, slices and maps have at least N elements, numbers are at least N
+//	max=N       strings, slices and maps have at most N elements, numbers are at most N
+//	len=N       strings, slices and maps have exactly N elements
+//	oneof=a b   the value is one of the listed ones, for strings and numbers
+//
+// Lengths of strings are counted in runes. Rules of pointers apply to the values
+// they point to, if not nil.
+package validate
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Validator is implemented by values that check themselves, it's called after
+// the rules of their fields. Errors may be Errors, to report specific fields.
+type Validator interface {
+	Validate() error
+}
+
+// FieldError describes a field that failed a rule.
+type FieldError struct {
+	// Field is the path of the field as in JSON, e.g. "card.recipient" or "cards[1].text".
+	Field string `json:"field"`
+	// Rule is the failed rule, e.g. "max=64", or "validate" for errors of Validate methods.
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+func (e FieldError) Error() string {
+	if len(e.Field) == 0 {
+		return e.Message
+	}
+	return e.Field + ": " + e.Message
+}
+
+// Errors are all field errors of a value.
+type Errors []FieldError
+
+func (e Errors) Error() string {
+	msgs := make([]string, 0, len(e))
+	for _, fieldErr := range e {
+		msgs = append(msgs, fieldErr.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Struct validates fields of the struct v points to, along with nested structs,
+// elements of slices and values of maps. It returns Errors or nil, there is nothing
+// to validate if v is nil.
+func Struct(v interface{}) error {
+	var errs Errors
+	walk(&errs, "", reflect.ValueOf(v))
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+var validatorTyp = reflect.TypeOf((*Validator)(nil)).Elem()
+
+// walk descends into the value, validating fields of structs by their tags,
+// then calling Validate methods.
+func walk(errs *Errors, path string, v reflect.Value) {
+	if !v.IsValid() {
+		// nil, or a nil interface of a field
+		return
+	}
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if !v.IsNil() {
+			// Validate methods of pointers are found by the addressable value
+			walk(errs, path, v.Elem())
+		}
+		return
+	case reflect.Struct:
+		walkStruct(errs, path, v)
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			walk(errs, fmt.Sprintf("%s[%d]", path, i), v.Index(i))
+		}
+	case reflect.Map:
+		for _, key := range v.MapKeys() {
+			walk(errs, fmt.Sprintf("%s[%v]", path, key.Interface()), v.MapIndex(key))
+		}
+	}
+	if v.Type().Implements(validatorTyp) {
+		callValidate(errs, path, v)
+	} else if v.CanAddr() && v.Addr().Type().Implements(validatorTyp) {
+		callValidate(errs, path, v.Addr())
+	}
+}
+
+func walkStruct(errs *Errors, path string, v reflect.Value) {
+	typ := v.Type()
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if len(field.PkgPath) > 0 {
+			// unexported
+			continue
+		}
+		name, ok := fieldName(field)
+		if !ok {
+			continue
+		}
+		fieldPath := path
+		if len(name) > 0 {
+			fieldPath = joinPath(path, name)
+		}
+		fv := v.Field(i)
+		if rules := field.Tag.Get("validate"); len(rules) > 0 {
+			if !checkRules(errs, fieldPath, rules, fv) {
+				continue
+			}
+		}
+		walk(errs, fieldPath, fv)
+	}
+}
+
+// fieldName returns the JSON key of the field, it's empty for embedded structs
+// that are flattened.
+func fieldName(field reflect.StructField) (string, bool) {
+	tag := strings.Split(field.Tag.Get("json"), ",")[0]
+	switch {
+	case tag == "-":
+		return "", false
+	case len(tag) > 0:
+		return tag, true
+	case field.Anonymous && indirect(field.Type).Kind() == reflect.Struct:
+		return "", true
+	default:
+		return field.Name, true
+	}
+}
+
+func indirect(typ reflect.Type) reflect.Type {
+	if typ.Kind() == reflect.Ptr {
+		return typ.Elem()
+	}
+	return typ
+}
+
+func joinPath(path, name string) string {
+	if len(path) == 0 {
+		return name
+	}
+	return path + "." + name
+}
+
+func callValidate(errs *Errors, path string, v reflect.Value) {
+	err := v.Interface().(Validator).Validate()
+	if err == nil {
+		return
+	}
+	if fieldErrs, ok := err.(Errors); ok {
+		for _, fieldErr := range fieldErrs {
+			fieldErr.Field = joinPath(path, fieldErr.Field)
+			*errs = append(*errs, fieldErr)
+		}
+		return
+	}
+	*errs = append(*errs, FieldError{
+		Field:   path,
+		Rule:    "validate",
+		Message: err.Error(),
+	})
+}
+
+// checkRules checks the value against the comma-separated rules, it reports
+// whether nested values should be validated too.
+func checkRules(errs *Errors, path, rules string, v reflect.Value) bool {
+	ruleList := strings.Split(rules, ",")
+	for _, rule := range ruleList {
+		if rule == "omitempty" && isZero(v) {
+			return false
+		}
+	}
+	for _, rule := range ruleList {
+		name, arg := rule, ""
+		if i := strings.Index(rule, "="); i > 0 {
+			name, arg = rule[:i], rule[i+1:]
+		}
+		var msg string
+		switch name {
+		case "omitempty":
+		case "required":
+			if isZero(v) {
+				msg = "is required"
+			}
+		case "min", "max", "len":
+			msg = checkBound(name, arg, v)
+		case "oneof":
+			msg = checkOneOf(arg, v)
+		default:
+			msg = fmt.Sprintf("unknown rule %s", name)
+		}
+		if len(msg) > 0 {
+			*errs = append(*errs, FieldError{
+				Field:   path,
+				Rule:    rule,
+				Message: msg,
+			})
+			return false
+		}
+	}
+	return true
+}
+
+// CheckRules returns an error if any of the comma-separated rules is unknown or
+// has an invalid argument, e.g. so generators reject rules that would fail every call.
+func CheckRules(rules string) error {
+	for _, rule := range strings.Split(rules, ",") {
+		name, arg := rule, ""
+		if i := strings.Index(rule, "="); i > 0 {
+			name, arg = rule[:i], rule[i+1:]
+		}
+		switch name {
+		case "omitempty", "required":
+		case "min", "max", "len":
+			if _, err := strconv.ParseFloat(arg, 64); err != nil {
+				return fmt.Errorf("invalid %s bound %q", name, arg)
+			}
+		case "oneof":
+			if len(strings.Fields(arg)) == 0 {
+				return fmt.Errorf("rule oneof has no values")
+			}
+		default:
+			return fmt.Errorf("unknown rule %s", name)
+		}
+	}
+	return nil
+}
+
+func isZero(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Slice, reflect.Map:
+		return v.Len() == 0
+	case reflect.Ptr, reflect.Interface:
+		return v.IsNil()
+	}
+	return reflect.DeepEqual(v.Interface(), reflect.Zero(v.Type()).Interface())
+}
+
+func checkBound(name, arg string, v reflect.Value) string {
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return ""
+		}
+		v = v.Elem()
+	}
+	bound, err := strconv.ParseFloat(arg, 64)
+	if err != nil {
+		return fmt.Sprintf("invalid %s bound %q", name, arg)
+	}
+	var (
+		n     float64
+		units string
+	)
+	switch v.Kind() {
+	case reflect.String:
+		n, units = float64(utf8.RuneCountInString(v.String())), " characters"
+	case reflect.Slice, reflect.Array, reflect.Map:
+		n, units = float64(v.Len()), " elements"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n = float64(v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n = float64(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		n = v.Float()
+	default:
+		return fmt.Sprintf("rule %s doesn't apply to %s", name, v.Type())
+	}
+	switch {
+	case name == "min" && n < bound:
+		return fmt.Sprintf("must be at least %s%s", arg, units)
+	case name == "max" && n > bound:
+		return fmt.Sprintf("must be at most %s%s", arg, units)
+	case name == "len" && n != bound:
+		return fmt.Sprintf("must have exactly %s%s", arg, units)
+	}
+	return ""
+}
+
+func checkOneOf(arg string, v reflect.Value) string {
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return ""
+		}
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+	default:
+		return fmt.Sprintf("rule oneof doesn't apply to %s", v.Type())
+	}
+	value := fmt.Sprint(v.Interface())
+	options := strings.Fields(arg)
+	for _, opt := range options {
+		if opt == value {
+			return ""
+		}
+	}
+	return fmt.Sprintf("must be one of %s", strings.Join(options, ", "))
+}
@@ -0,0 +1,218 @@
+package validate
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type testCard struct {
+	Recipient string            `json:"recipient" validate:"required,max=8"`
+	Text      string            `json:"text,omitempty" validate:"omitempty,min=2"`
+	Code      string            `json:"code" validate:"omitempty,len=3"`
+	Kind      string            `json:"kind" validate:"oneof=letter postcard"`
+	Copies    int               `json:"copies" validate:"min=1,max=3"`
+	Rating    *float64          `json:"rating" validate:"max=5"`
+	Tags      []string          `json:"tags" validate:"max=2"`
+	Props     map[string]string `json:"props" validate:"omitempty,len=1"`
+	Stamp     *testStamp        `json:"stamp"`
+	Stamps    []testStamp       `json:"stamps"`
+	Extra     interface{}       `json:"extra"`
+	Skipped   string            `json:"-" validate:"required"`
+	private   string            `validate:"required"`
+	Meta
+}
+
+type Meta struct {
+	Sender string `validate:"required"`
+}
+
+type testStamp struct {
+	Price int `json:"price" validate:"required"`
+}
+
+// Validate is defined on the pointer, so it's called for addressable stamps only.
+func (s *testStamp) Validate() error {
+	if s.Price > 100 {
+		return errors.New("is too expensive")
+	}
+	return nil
+}
+
+type testAddress string
+
+func (a testAddress) Validate() error {
+	if len(a) > 0 && a[0] == '?' {
+		return Errors{{Field: "line", Rule: "validate", Message: "is unknown"}}
+	}
+	return nil
+}
+
+type testEnvelope struct {
+	Card    **testCard            `json:"card" validate:"required"`
+	Address testAddress           `json:"address"`
+	ByName  map[string]*testStamp `json:"by_name"`
+}
+
+func validCard() *testCard {
+	return &testCard{
+		Recipient: "John",
+		Kind:      "letter",
+		Copies:    1,
+		Meta:      Meta{Sender: "Jane"},
+	}
+}
+
+func TestStruct(t *testing.T) {
+	float := func(f float64) *float64 { return &f }
+	tests := []struct {
+		name   string
+		modify func(c *testCard)
+		want   []FieldError
+	}{{
+		name:   "valid",
+		modify: func(c *testCard) {},
+	}, {
+		name:   "required",
+		modify: func(c *testCard) { c.Recipient = "" },
+		want:   []FieldError{{"recipient", "required", "is required"}},
+	}, {
+		name:   "max of runes",
+		modify: func(c *testCard) { c.Recipient = "Жан-Поль Сартр" },
+		want:   []FieldError{{"recipient", "max=8", "must be at most 8 characters"}},
+	}, {
+		name:   "omitempty with min",
+		modify: func(c *testCard) { c.Text = "a" },
+		want:   []FieldError{{"text", "min=2", "must be at least 2 characters"}},
+	}, {
+		name:   "len",
+		modify: func(c *testCard) { c.Code = "ab" },
+		want:   []FieldError{{"code", "len=3", "must have exactly 3 characters"}},
+	}, {
+		name:   "oneof",
+		modify: func(c *testCard) { c.Kind = "parcel" },
+		want:   []FieldError{{"kind", "oneof=letter postcard", "must be one of letter, postcard"}},
+	}, {
+		name:   "min of numbers",
+		modify: func(c *testCard) { c.Copies = 0 },
+		want:   []FieldError{{"copies", "min=1", "must be at least 1"}},
+	}, {
+		name:   "max of a pointer",
+		modify: func(c *testCard) { c.Rating = float(5.5) },
+		want:   []FieldError{{"rating", "max=5", "must be at most 5"}},
+	}, {
+		name:   "max of slices",
+		modify: func(c *testCard) { c.Tags = []string{"a", "b", "c"} },
+		want:   []FieldError{{"tags", "max=2", "must be at most 2 elements"}},
+	}, {
+		name:   "len of maps",
+		modify: func(c *testCard) { c.Props = map[string]string{"a": "1", "b": "2"} },
+		want:   []FieldError{{"props", "len=1", "must have exactly 1 elements"}},
+	}, {
+		name:   "embedded struct",
+		modify: func(c *testCard) { c.Sender = "" },
+		want:   []FieldError{{"Sender", "required", "is required"}},
+	}, {
+		name:   "nested pointer",
+		modify: func(c *testCard) { c.Stamp = &testStamp{} },
+		want:   []FieldError{{"stamp.price", "required", "is required"}},
+	}, {
+		name:   "Validate of a nested pointer",
+		modify: func(c *testCard) { c.Stamp = &testStamp{Price: 200} },
+		want:   []FieldError{{"stamp", "validate", "is too expensive"}},
+	}, {
+		name:   "slice elements",
+		modify: func(c *testCard) { c.Stamps = []testStamp{{Price: 1}, {Price: 200}} },
+		want:   []FieldError{{"stamps[1]", "validate", "is too expensive"}},
+	}, {
+		name:   "interface",
+		modify: func(c *testCard) { c.Extra = &testStamp{} },
+		want:   []FieldError{{"extra.price", "required", "is required"}},
+	}, {
+		name: "all fields",
+		modify: func(c *testCard) {
+			c.Recipient = ""
+			c.Copies = 4
+		},
+		want: []FieldError{
+			{"recipient", "required", "is required"},
+			{"copies", "max=3", "must be at most 3"},
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			card := validCard()
+			tt.modify(card)
+			err := Struct(card)
+			if len(tt.want) == 0 {
+				if err != nil {
+					t.Errorf("Struct() = %v, want nil", err)
+				}
+				return
+			}
+			if got, ok := err.(Errors); !ok || !reflect.DeepEqual([]FieldError(got), tt.want) {
+				t.Errorf("Struct() = %#v, want %#v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestStructPointers(t *testing.T) {
+	var nilCard *testCard
+	for _, v := range []interface{}{nil, nilCard, &testEnvelope{}} {
+		err := Struct(v)
+		if _, ok := v.(*testEnvelope); ok {
+			want := Errors{{"card", "required", "is required"}}
+			if !reflect.DeepEqual(err, want) {
+				t.Errorf("Struct() of a nil nested pointer = %v, want %v", err, want)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Struct(%#v) = %v, want nil", v, err)
+		}
+	}
+
+	card := validCard()
+	card.Copies = 0
+	env := &testEnvelope{
+		Card:    &card,
+		Address: "?",
+		ByName:  map[string]*testStamp{"big": {Price: 200}, "nil": nil},
+	}
+	want := Errors{
+		{"card.copies", "min=1", "must be at least 1"},
+		{"address.line", "validate", "is unknown"},
+		{"by_name[big]", "validate", "is too expensive"},
+	}
+	if err := Struct(env); !reflect.DeepEqual(err, want) {
+		t.Errorf("Struct() = %v, want %v", err, want)
+	}
+}
+
+func TestUnknownRule(t *testing.T) {
+	v := &struct {
+		Name string `validate:"email"`
+		Flag bool   `validate:"max=1"`
+	}{}
+	want := Errors{
+		{"Name", "email", "unknown rule email"},
+		{"Flag", "max=1", "rule max doesn't apply to bool"},
+	}
+	if err := Struct(v); !reflect.DeepEqual(err, want) {
+		t.Errorf("Struct() = %v, want %v", err, want)
+	}
+}
+
+func TestCheckRules(t *testing.T) {
+	for _, rules := range []string{"required", "omitempty,min=1,max=64", "len=2", "oneof=a b"} {
+		if err := CheckRules(rules); err != nil {
+			t.Errorf("CheckRules(%q) = %v", rules, err)
+		}
+	}
+	for _, rules := range []string{"maxlen=3", "required,max=ten", "len", "oneof=", ""} {
+		if err := CheckRules(rules); err == nil {
+			t.Errorf("CheckRules(%q) succeeded", rules)
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/astranet/meshRPC/generator"
+)
+
+// watchInterval is how often the watched files are polled for changes.
+const watchInterval = 500 * time.Millisecond
+
+// watchExpose regenerates the handler and the client each time the service interface
+// or any of the types reachable from it change. Changes are applied without a prompt,
+// wire format changes are reported along with compile errors of regenerated packages.
+func watchExpose(basePath string, opt *generator.Options) {
+	var (
+		prev  *generator.Schema
+		files = watchedFiles(basePath, nil)
+	)
+	log.Printf("Watching %d files, press Ctrl+C to stop.", len(files))
+	for {
+		stamps := stampFiles(files)
+		actionQueue, schema, err := exposeActions(basePath, opt)
+		if err != nil {
+			log.Println(err)
+		} else {
+			if prev != nil {
+				for _, change := range generator.DiffSchemas(prev, schema) {
+					fmt.Println(change)
+				}
+			}
+			prev = schema
+			if next := watchedFiles(basePath, schema); !reflect.DeepEqual(next, files) {
+				log.Printf("Watching %d files.", len(next))
+				files = next
+				stamps = restampFiles(files, stamps)
+			}
+			if _, changed := actionQueue.Diff(); changed {
+				if actionQueue.Exec() {
+					checkBuild(basePath, opt.ClientOut)
+				}
+			} else {
+				log.Println("Generated files are up to date.")
+			}
+		}
+		waitForChange(files, stamps)
+	}
+}
+
+// watchedFiles returns the source files of the schema, along with all non-generated
+// Go files of the service package, so files that start to declare types are noticed too.
+func watchedFiles(basePath string, schema *generator.Schema) []string {
+	set := make(map[string]bool)
+	if schema != nil {
+		for _, file := range schema.SrcFiles() {
+			set[file] = true
+		}
+	}
+	matches, _ := filepath.Glob(filepath.Join(basePath, "*.go"))
+	for _, file := range matches {
+		if strings.HasSuffix(file, "_gen.go") || strings.HasSuffix(file, "_test.go") {
+			continue
+		}
+		set[file] = true
+	}
+	files := make([]string, 0, len(set))
+	for file := range set {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+	return files
+}
+
+// fileStamp identifies a revision of a file without reading it,
+// a zero stamp is used for files that don't exist.
+type fileStamp struct {
+	modTime time.Time
+	size    int64
+}
+
+func stampFiles(files []string) map[string]fileStamp {
+	stamps := make(map[string]fileStamp, len(files))
+	for _, file := range files {
+		stamps[file] = stampFile(file)
+	}
+	return stamps
+}
+
+// restampFiles returns stamps of the files, keeping the given ones of files that have
+// been watched already, so their changes since then are not missed.
+func restampFiles(files []string, stamps map[string]fileStamp) map[string]fileStamp {
+	next := make(map[string]fileStamp, len(files))
+	for _, file := range files {
+		if stamp, ok := stamps[file]; ok {
+			next[file] = stamp
+			continue
+		}
+		next[file] = stampFile(file)
+	}
+	return next
+}
+
+func stampFile(path string) fileStamp {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fileStamp{}
+	}
+	return fileStamp{
+		modTime: info.ModTime(),
+		size:    info.Size(),
+	}
+}
+
+func waitForChange(files []string, stamps map[string]fileStamp) {
+	for {
+		time.Sleep(watchInterval)
+		for _, file := range files {
+			if stampFile(file) != stamps[file] {
+				log.Println("Changed:", projectPath(file))
+				return
+			}
+		}
+	}
+}
+
+// checkBuild compiles the regenerated packages and prints errors, if any.
+func checkBuild(dirs ...string) {
+	for _, dir := range dirs {
+		if len(dir) == 0 {
+			continue
+		}
+		cmd := exec.Command("go", "build", "-o", os.DevNull, ".")
+		cmd.Dir = dir
+		out := new(bytes.Buffer)
+		cmd.Stdout = out
+		cmd.Stderr = out
+		if err := cmd.Run(); err != nil {
+			log.Printf("Package %s doesn't compile: %v\n%s", projectPath(dir), err, out.String())
+		}
+	}
+}
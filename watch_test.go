@@ -0,0 +1,78 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestWatchedFiles(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+	for _, name := range []string{"service.go", "models.go", "handler_gen.go", "service_test.go", "README.md"} {
+		writeFile(t, filepath.Join(dir, name), "package service\n", 0644)
+	}
+	want := []string{
+		filepath.Join(dir, "models.go"),
+		filepath.Join(dir, "service.go"),
+	}
+	if got := watchedFiles(dir, nil); !reflect.DeepEqual(got, want) {
+		t.Errorf("watchedFiles() = %v, want %v", got, want)
+	}
+}
+
+func TestWaitForChange(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "service.go")
+	writeFile(t, path, "package service\n", 0644)
+	missing := filepath.Join(dir, "models.go")
+	if stamp := stampFile(missing); stamp != (fileStamp{}) {
+		t.Errorf("stamp of a missing file = %v, want a zero one", stamp)
+	}
+
+	files := []string{path, missing}
+	for _, change := range []func(){
+		func() { writeFile(t, path, "package service\n\ntype Service interface{}\n", 0644) },
+		func() { writeFile(t, missing, "package service\n", 0644) },
+		func() { os.Remove(missing) },
+	} {
+		stamps := stampFiles(files)
+		done := make(chan struct{})
+		go func() {
+			waitForChange(files, stamps)
+			close(done)
+		}()
+		change()
+		select {
+		case <-done:
+		case <-time.After(10 * watchInterval):
+			t.Fatal("waitForChange() missed the change")
+		}
+	}
+}
+
+func TestRestampFiles(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+	kept := filepath.Join(dir, "service.go")
+	added := filepath.Join(dir, "models.go")
+	dropped := filepath.Join(dir, "types.go")
+	for _, path := range []string{kept, added, dropped} {
+		writeFile(t, path, "package service\n", 0644)
+	}
+	stamps := stampFiles([]string{kept, dropped})
+	// an edit made while the files were being generated
+	writeFile(t, kept, "package service\n\ntype Service interface{}\n", 0644)
+
+	next := restampFiles([]string{kept, added}, stamps)
+	want := map[string]fileStamp{
+		kept:  stamps[kept],
+		added: stampFile(added),
+	}
+	if !reflect.DeepEqual(next, want) {
+		t.Errorf("restampFiles() = %v, want %v", next, want)
+	}
+}